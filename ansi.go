@@ -0,0 +1,191 @@
+package fmt
+
+// =============================================================================
+// ANSI STYLING - Terminal colours and text attributes
+// =============================================================================
+
+// Color is an ANSI foreground colour code (SGR parameter)
+type Color uint8
+
+// Ansi exposes the terminal colours, following the Msg and D naming convention
+var Ansi = struct {
+	Default Color
+	Red     Color
+	Green   Color
+	Yellow  Color
+	Blue    Color
+	Magenta Color
+	Cyan    Color
+	White   Color
+	Gray    Color
+}{39, 31, 32, 33, 34, 35, 36, 37, 90}
+
+const (
+	escChar    = '\x1b'
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiUnderl = "\x1b[4m"
+)
+
+// colorEnabled is set from the environment (see detectColor) and UseColor
+var colorEnabled = detectColor()
+
+// UseColor turns ANSI styling on or off for the whole program, overriding
+// the environment detection (NO_COLOR, TERM=dumb, browser). Call it at startup.
+// With colour off, Color, Bold, Underline and TypeColor leave text unchanged.
+func UseColor(on bool) {
+	colorEnabled = on
+}
+
+// ColorEnabled reports whether ANSI styling is currently applied
+func ColorEnabled() bool {
+	return colorEnabled
+}
+
+// Color returns the default terminal colour of the message type:
+// red for errors (network types included), yellow for warnings, green for
+// success, cyan for info, gray for debug and the default colour for Normal.
+func (t MessageType) Color() Color {
+	switch t {
+	case Msg.Normal:
+		return Ansi.Default
+	case Msg.Info:
+		return Ansi.Cyan
+	case Msg.Warning:
+		return Ansi.Yellow
+	case Msg.Success:
+		return Ansi.Green
+	case Msg.Debug:
+		return Ansi.Gray
+	}
+	if def, ok := getMsgType(t); ok {
+		return def.color // Registered with AddMessageType
+	}
+	return Ansi.Red
+}
+
+// Color wraps the content in the given ANSI colour
+// eg: Convert("ok").Color(Ansi.Green).String() => "\x1b[32mok\x1b[0m"
+func (c *Conv) Color(color Color) *Conv {
+	if !colorEnabled {
+		return c
+	}
+	var code [8]byte
+	n := copy(code[:], "\x1b[")
+	if color >= 10 {
+		if color >= 100 {
+			code[n] = '0' + byte(color/100)
+			n++
+		}
+		code[n] = '0' + byte(color/10%10)
+		n++
+	}
+	code[n] = '0' + byte(color%10)
+	n++
+	code[n] = 'm'
+	n++
+	return c.wrapOut(string(code[:n]), ansiReset)
+}
+
+// Bold wraps the content in the ANSI bold attribute
+func (c *Conv) Bold() *Conv {
+	if !colorEnabled {
+		return c
+	}
+	return c.wrapOut(ansiBold, ansiReset)
+}
+
+// Underline wraps the content in the ANSI underline attribute
+func (c *Conv) Underline() *Conv {
+	if !colorEnabled {
+		return c
+	}
+	return c.wrapOut(ansiUnderl, ansiReset)
+}
+
+// TypeColor colours the content with the colour of the message type
+// eg: msg, t := Translate(D.Failed).StringType(); Convert(msg).TypeColor(t).String()
+func (c *Conv) TypeColor(t MessageType) *Conv {
+	if t == Msg.Normal {
+		return c
+	}
+	return c.Color(t.Color())
+}
+
+// ansiSeqEnd returns the index after the escape sequence starting at s[i]
+// (CSI "ESC [ params final" or a two byte "ESC x" sequence)
+func ansiSeqEnd(s string, i int) int {
+	i++ // ESC
+	if i < len(s) && s[i] == '[' {
+		i++
+		for i < len(s) && (s[i] < 0x40 || s[i] > 0x7E) {
+			i++
+		}
+	}
+	if i < len(s) {
+		i++ // final byte
+	}
+	return i
+}
+
+// hasEscape reports whether s contains an ANSI escape character
+func hasEscape(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] == escChar {
+			return true
+		}
+	}
+	return false
+}
+
+// visibleLen returns the width of s ignoring ANSI escape sequences,
+// counted in runes (runes=true) or bytes
+func visibleLen(s string, runes bool) int {
+	n := 0
+	for i := 0; i < len(s); {
+		if s[i] == escChar {
+			i = ansiSeqEnd(s, i)
+			continue
+		}
+		if !runes || s[i]&0xC0 != 0x80 {
+			n++
+		}
+		i++
+	}
+	return n
+}
+
+// visiblePrefix returns the first n visible runes (or bytes) of s keeping the
+// escape sequences met before the cut. If styling was cut open a reset is
+// appended so the colour does not leak into the following text.
+// A byte count never splits a multi-byte character.
+func visiblePrefix(s string, n int, runes bool) string {
+	count, styled := 0, false
+	end := len(s)
+	for i := 0; i < len(s); {
+		if s[i] == escChar {
+			i = ansiSeqEnd(s, i)
+			styled = true
+			continue
+		}
+		start := s[i]&0xC0 != 0x80
+		if (!runes || start) && count == n {
+			end = i
+			if !runes {
+				end = runeBoundary(s, i)
+			}
+			break
+		}
+		if !runes || start {
+			count++
+		}
+		i++
+	}
+	if end == len(s) {
+		return s
+	}
+	if styled {
+		return s[:end] + ansiReset
+	}
+	return s[:end]
+}
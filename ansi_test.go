@@ -0,0 +1,80 @@
+package fmt
+
+import "testing"
+
+func TestAnsiStyling(t *testing.T) {
+	defer UseColor(ColorEnabled())
+	UseColor(true)
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"color", Convert("ok").Color(Ansi.Green).String(), "\x1b[32mok\x1b[0m"},
+		{"gray", Convert("dbg").Color(Ansi.Gray).String(), "\x1b[90mdbg\x1b[0m"},
+		{"bold", Convert("b").Bold().String(), "\x1b[1mb\x1b[0m"},
+		{"underline", Convert("u").Underline().String(), "\x1b[4mu\x1b[0m"},
+		{"type error", Convert("x").TypeColor(Msg.Error).String(), "\x1b[31mx\x1b[0m"},
+		{"type timeout", Convert("x").TypeColor(Msg.Timeout).String(), "\x1b[31mx\x1b[0m"},
+		{"type warning", Convert("x").TypeColor(Msg.Warning).String(), "\x1b[33mx\x1b[0m"},
+		{"type normal", Convert("x").TypeColor(Msg.Normal).String(), "x"},
+		{"empty", Convert("").Bold().String(), ""},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.want, tt.got)
+		}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		UseColor(false)
+		defer UseColor(true)
+		if got := Convert("ok").Color(Ansi.Red).Bold().String(); got != "ok" {
+			t.Errorf("expected plain text with colour off, got %q", got)
+		}
+	})
+
+	t.Run("padding ignores escapes", func(t *testing.T) {
+		red := Convert("abc").Color(Ansi.Red).String()
+		if got := Fmt("%-6s|", red); got != red+"   |" {
+			t.Errorf("unexpected left padding %q", got)
+		}
+		if got := Fmt("%6s|", red); got != "   "+red+"|" {
+			t.Errorf("unexpected right padding %q", got)
+		}
+		// Longer than the width: cut keeps the colour and resets it
+		if got := Fmt("%2s", red); got != "\x1b[31mab\x1b[0m" {
+			t.Errorf("unexpected cut %q", got)
+		}
+	})
+
+	t.Run("Truncate ignores escapes", func(t *testing.T) {
+		green := Convert("Hello").Color(Ansi.Green).String()
+		if got := Convert(green).Truncate(5).String(); got != green {
+			t.Errorf("expected no truncation for 5 visible bytes, got %q", got)
+		}
+		if got := Convert(green).Truncate(4).String(); got != "\x1b[32mH\x1b[0m..." {
+			t.Errorf("unexpected truncation %q", got)
+		}
+		if got := Convert(green).Truncate(2).String(); got != "\x1b[32mHe\x1b[0m" {
+			t.Errorf("unexpected short truncation %q", got)
+		}
+	})
+}
+
+func TestVisibleLen(t *testing.T) {
+	s := "\x1b[1m\x1b[31mñandú\x1b[0m"
+	if got := visibleLen(s, true); got != 5 {
+		t.Errorf("expected 5 runes, got %d", got)
+	}
+	if got := visibleLen(s, false); got != 7 {
+		t.Errorf("expected 7 bytes, got %d", got)
+	}
+	if got := visiblePrefix(s, 2, true); got != "\x1b[1m\x1b[31mña\x1b[0m" {
+		t.Errorf("unexpected prefix %q", got)
+	}
+	if got := visiblePrefix("plain", 10, true); got != "plain" {
+		t.Errorf("expected unchanged text, got %q", got)
+	}
+}
@@ -204,7 +204,7 @@ func BenchmarkHighDemandProcesses(b *testing.B) {
 
 	b.Run("ErrorMessageConstruction", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
-			out := Err("Operation failed for user", "Alice", "with error code", 500).String()
+			out := Err("Operation failed for user", "Alice", "with error code", 500).Error()
 			_ = out
 		}
 	})
@@ -0,0 +1,136 @@
+package fmt
+
+// =============================================================================
+// BIDI SUPPORT - Text direction and isolation for right-to-left languages
+// =============================================================================
+
+// Unicode directional isolates (UAX #9)
+const (
+	fsiStr = "\u2068" // First Strong Isolate: direction taken from the content
+	pdiStr = "\u2069" // Pop Directional Isolate: closes any of the above
+
+	bdiOpen  = "<bdi>"
+	bdiClose = "</bdi>"
+)
+
+// IsRTL reports whether the language is written right-to-left (AR and its regional variants)
+func (l lang) IsRTL() bool {
+	return l.baseLang() == AR
+}
+
+// Dir returns the text direction of the language as used by the HTML dir attribute: "rtl" or "ltr"
+func (l lang) Dir() string {
+	if l.IsRTL() {
+		return "rtl"
+	}
+	return "ltr"
+}
+
+// IsRTL reports whether the scope language is written right-to-left
+func (s LangScope) IsRTL() bool {
+	return s.l.IsRTL()
+}
+
+// Dir returns the text direction of the scope language: "rtl" or "ltr"
+// eg: Html(`<html lang="%s" dir="%s">`, Lang(AR), Lang(AR).Dir())
+func (s LangScope) Dir() string {
+	return s.l.Dir()
+}
+
+// OutDir returns the text direction of the current output language: "rtl" or "ltr"
+func OutDir() string {
+	return getCurrentLang().Dir()
+}
+
+// Isolate wraps the content in Unicode First Strong Isolate (U+2068) and
+// Pop Directional Isolate (U+2069) so embedded values such as numbers, paths
+// or Latin names keep their own direction inside right-to-left text.
+// eg: Translate(AR, D.Files, Convert("/tmp/a.txt").Isolate().String())
+func (c *Conv) Isolate() *Conv {
+	return c.wrapOut(fsiStr, pdiStr)
+}
+
+// Bdi wraps the content in <bdi></bdi>, the HTML equivalent of Isolate.
+// The content is not escaped.
+// eg: Html("<p>", D.Value, ": ", Convert("v1.2").Bdi().String(), "</p>")
+func (c *Conv) Bdi() *Conv {
+	return c.wrapOut(bdiOpen, bdiClose)
+}
+
+// wrapOut surrounds BuffOut content with prefix and suffix
+func (c *Conv) wrapOut(prefix, suffix string) *Conv {
+	if c.hasContent(BuffErr) {
+		return c // Error chain interruption
+	}
+	if c.outLen == 0 {
+		return c
+	}
+	c.ResetBuffer(BuffWork)
+	c.WrString(BuffWork, prefix)
+	c.wrBytes(BuffWork, c.out[:c.outLen])
+	c.WrString(BuffWork, suffix)
+	c.swapBuff(BuffWork, BuffOut)
+	return c
+}
+
+// isRTLRune reports whether r belongs to a right-to-left script block
+// (Hebrew, Arabic, Syriac, Thaana, NKo and their presentation forms)
+func isRTLRune(r rune) bool {
+	return (r >= 0x0590 && r <= 0x08FF) ||
+		(r >= 0xFB1D && r <= 0xFDFF) ||
+		(r >= 0xFE70 && r <= 0xFEFF)
+}
+
+// needsIsolation reports whether s contains left-to-right content (letters or
+// digits outside right-to-left scripts) that should be isolated inside RTL text.
+// Punctuation and whitespace only strings (e.g. ":" or "\n") do not.
+func needsIsolation(s string) bool {
+	for _, r := range s {
+		switch {
+		case (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z'):
+			return true
+		case r < 0x80, isRTLRune(r):
+			continue
+		case r >= 0x2000 && r <= 0x206F:
+			continue // General punctuation, spaces and bidi controls
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// runeLen returns the number of runes in s
+func runeLen(s string) int {
+	n := 0
+	for range s {
+		n++
+	}
+	return n
+}
+
+// runePrefix returns the first n runes of s
+func runePrefix(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	count := 0
+	for i := range s {
+		if count == n {
+			return s[:i]
+		}
+		count++
+	}
+	return s
+}
+
+// runeBoundary returns the largest index <= n that does not split a UTF-8 sequence in s
+func runeBoundary(s string, n int) int {
+	if n >= len(s) {
+		return len(s)
+	}
+	for n > 0 && s[n]&0xC0 == 0x80 {
+		n--
+	}
+	return n
+}
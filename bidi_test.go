@@ -0,0 +1,147 @@
+package fmt
+
+import "testing"
+
+func TestDirection(t *testing.T) {
+	keepVariants(t)
+	defer OutLang(EN)
+
+	if !AR.IsRTL() || AR.Dir() != "rtl" {
+		t.Errorf("expected AR to be rtl, got %q", AR.Dir())
+	}
+	if EN.IsRTL() || ES.Dir() != "ltr" {
+		t.Errorf("expected ES to be ltr, got %q", ES.Dir())
+	}
+	if got := Lang("ar-EG").Dir(); got != "rtl" {
+		t.Errorf("expected Lang(ar-EG).Dir() 'rtl', got %q", got)
+	}
+
+	arSA := AddVariant("ar-SA", D.Value, "قيمة")
+	if !arSA.IsRTL() {
+		t.Error("expected regional Arabic variant to be rtl")
+	}
+
+	OutLang(AR)
+	if got := OutDir(); got != "rtl" {
+		t.Errorf("expected OutDir 'rtl', got %q", got)
+	}
+	OutLang(EN)
+	if got := OutDir(); got != "ltr" {
+		t.Errorf("expected OutDir 'ltr', got %q", got)
+	}
+}
+
+func TestIsolateHelpers(t *testing.T) {
+	if got := Convert("/tmp/a.txt").Isolate().String(); got != "⁨/tmp/a.txt⁩" {
+		t.Errorf("unexpected Isolate output %q", got)
+	}
+	if got := Convert(42).Bdi().String(); got != "<bdi>42</bdi>" {
+		t.Errorf("unexpected Bdi output %q", got)
+	}
+	if got := Convert("").Isolate().String(); got != "" {
+		t.Errorf("expected empty output, got %q", got)
+	}
+}
+
+func TestRTLTranslateIsolation(t *testing.T) {
+	ar := Lang(AR).Isolated()
+
+	t.Run("no isolates by default", func(t *testing.T) {
+		if got := Translate(AR, D.Value, 42).String(); got != "قيمة 42" {
+			t.Errorf("unexpected output %q", got)
+		}
+		if got := Err(AR, D.Files, "/usr/bin", D.Not, D.Found).Error(); got != "ملفات /usr/bin ليس موجود" {
+			t.Errorf("unexpected error %q", got)
+		}
+		if got := Html(AR, "<p>", D.Value, 42, "</p>").String(); got != "<p>قيمة42</p>" {
+			t.Errorf("unexpected html %q", got)
+		}
+	})
+
+	t.Run("values isolated in Isolated scope", func(t *testing.T) {
+		got := ar.Translate(D.Value, 42).String()
+		want := "قيمة \u206842\u2069"
+		if got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+		got = ar.Translate(D.Files, "/usr/bin", D.Not, D.Found).String()
+		want = "ملفات \u2068/usr/bin\u2069 ليس موجود"
+		if got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+		if !Lang(ar).isolate {
+			t.Error("expected Lang(scope) to keep the scope unchanged")
+		}
+	})
+
+	t.Run("errors never isolated", func(t *testing.T) {
+		got := ar.Err(D.Files, "/usr/bin", D.Not, D.Found).Error()
+		want := "ملفات /usr/bin ليس موجود"
+		if got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("punctuation and arabic strings untouched", func(t *testing.T) {
+		got := ar.Translate(D.Value, ":", "نص").String()
+		want := "قيمة: نص"
+		if got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("LTR languages untouched", func(t *testing.T) {
+		if got := Lang(EN).Isolated().Translate(D.Value, 42).String(); got != "Value 42" {
+			t.Errorf("expected 'Value 42', got %q", got)
+		}
+	})
+
+	t.Run("Html uses bdi for values", func(t *testing.T) {
+		got := ar.Html("<p>", D.Value, 42, "</p>").String()
+		want := "<p>قيمة<bdi>42</bdi></p>"
+		if got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+}
+
+func TestRTLWidthAndAlignment(t *testing.T) {
+	t.Run("width counts characters", func(t *testing.T) {
+		if got := Fmt("%-5s|", "عرب"); got != "عرب  |" {
+			t.Errorf("unexpected padding %q", got)
+		}
+		if got := Fmt("%5s|", "ñu"); got != "   ñu|" {
+			t.Errorf("unexpected padding %q", got)
+		}
+	})
+
+	t.Run("alignment kept in logical order in RTL", func(t *testing.T) {
+		ar := Lang(AR)
+		if got := ar.Fmt("%-5s|", "ab"); got != "ab   |" {
+			t.Errorf("unexpected left align %q", got)
+		}
+		if got := ar.Fmt("%5s|", "ab"); got != "   ab|" {
+			t.Errorf("unexpected right align %q", got)
+		}
+		OutLang(AR)
+		defer OutLang(EN)
+		if got := Fmt("%-6s|%4d|", "ab", 7); got != "ab    |   7|" {
+			t.Errorf("global RTL language must not change padding, got %q", got)
+		}
+		if got := ar.Fmt("%05d", 42); got != "00042" {
+			t.Errorf("zero padding must not be mirrored, got %q", got)
+		}
+	})
+}
+
+func TestTruncateRuneBoundary(t *testing.T) {
+	// Arabic letters take 2 bytes; cutting at an odd byte must not split a character
+	got := Convert("مرحبا بالعالم").Truncate(8).String()
+	if got != "مر..." {
+		t.Errorf("expected 'مر...', got %q", got)
+	}
+	got = Convert("مرحبا").Truncate(3, 1).String()
+	if got != "م" {
+		t.Errorf("expected 'م', got %q", got)
+	}
+}
@@ -4,7 +4,7 @@ package fmt
 // Returns the boolean value and any error that occurred
 func (c *Conv) Bool() (bool, error) {
 	if c.hasContent(BuffErr) {
-		return false, c
+		return false, c.newError()
 	}
 
 	// Optimized: Direct byte comparison without string allocation
@@ -49,8 +49,8 @@ func (c *Conv) Bool() (bool, error) {
 
 	// Keep inp for error reporting (this is the final usage)
 	inp = c.GetString(BuffOut) // Only allocation for error case
-	c.wrErr("Bool", D.Value, D.Invalid, inp)
-	return false, c
+	c.wrErrKind(ErrSyntax, "Bool", D.Value, D.Invalid, inp)
+	return false, c.newError()
 }
 
 // wrBool writes boolean value to specified buffer destination
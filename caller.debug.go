@@ -0,0 +1,78 @@
+//go:build fmtdebug
+
+package fmt
+
+import (
+	stdfmt "fmt"
+	"runtime"
+)
+
+// pkgPrefix identifies the frames of this package, trimmed from captured stacks
+const pkgPrefix = "github.com/tinywasm/fmt."
+
+// maxCallerFrames limits the number of frames kept per error
+const maxCallerFrames = 16
+
+// errCaller records the call stack where an error was created.
+// Only compiled with -tags fmtdebug; release builds use an empty struct.
+type errCaller struct {
+	frames []runtime.Frame
+}
+
+// captureCaller records the stack of the caller, skipping frames of this
+// package (Err, Errf, conversions...) and the runtime
+func captureCaller() errCaller {
+	var pcs [maxCallerFrames + 8]uintptr
+	n := runtime.Callers(2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var c errCaller
+	for len(c.frames) < maxCallerFrames {
+		f, more := frames.Next()
+		if !isInternalFrame(f) {
+			c.frames = append(c.frames, f)
+		}
+		if !more {
+			break
+		}
+	}
+	return c
+}
+
+// isInternalFrame reports frames of this package (tests excluded) and the runtime
+func isInternalFrame(f runtime.Frame) bool {
+	if HasSuffix(f.File, "_test.go") {
+		return false
+	}
+	return HasPrefix(f.Function, pkgPrefix) || HasPrefix(f.Function, "runtime.")
+}
+
+// String returns one "\n    at function (file:line)" line per captured frame
+func (ec errCaller) String() string {
+	if len(ec.frames) == 0 {
+		return ""
+	}
+	c := GetConv()
+	for _, f := range ec.frames {
+		c.WrString(BuffOut, "\n    at ")
+		c.WrString(BuffOut, f.Function)
+		c.WrString(BuffOut, " (")
+		c.WrString(BuffOut, f.File)
+		c.wrByte(BuffOut, ':')
+		c.wrIntBase(BuffOut, int64(f.Line), 10, true)
+		c.wrByte(BuffOut, ')')
+	}
+	return c.String()
+}
+
+// Format implements the standard library fmt.Formatter so %+v prints the
+// creation stack with the standard fmt package too (debug builds only).
+// Every other verb, with its width and flags, formats the message exactly
+// as release builds do.
+func (e *Error) Format(s stdfmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		s.Write([]byte(e.msg + e.caller.String()))
+		return
+	}
+	stdfmt.Fprintf(s, stdfmt.FormatString(s, verb), e.msg)
+}
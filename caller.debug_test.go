@@ -0,0 +1,56 @@
+//go:build fmtdebug
+
+package fmt
+
+import (
+	stdfmt "fmt"
+	"testing"
+)
+
+func TestCallerDebug(t *testing.T) {
+	err := Err(D.Format, D.Invalid)
+
+	got := Fmt("%+v", err)
+	if !Contains(got, "Format Invalid\n    at ") || !Contains(got, "caller.debug_test.go:11") {
+		t.Errorf("expected creation frame in %q", got)
+	}
+	if !Contains(got, "TestCallerDebug") {
+		t.Errorf("expected test function in %q", got)
+	}
+	// Frames of the package itself are trimmed
+	if Contains(got, "fmt.Err ") || Contains(got, "fmt.(*Conv)") || Contains(got, "runtime.") {
+		t.Errorf("expected internal frames trimmed, got %q", got)
+	}
+	if got := Fmt("%v", err); got != "Format Invalid" {
+		t.Errorf("expected plain message for %%v, got %q", got)
+	}
+
+	t.Run("standard fmt", func(t *testing.T) {
+		if got := stdfmt.Sprintf("%+v", err); !Contains(got, "caller.debug_test.go:11") {
+			t.Errorf("expected stack with standard fmt, got %q", got)
+		}
+		if got := stdfmt.Sprintf("%v", err); got != "Format Invalid" {
+			t.Errorf("unexpected %%v output %q", got)
+		}
+		// Other verbs keep width and flags, like an error without Format
+		for _, f := range []string{"[%-18s]", "[%x]", "[% X]", "[%q]", "[%#q]", "[%20v]", "[%.6s]"} {
+			if got, want := stdfmt.Sprintf(f, err), stdfmt.Sprintf(f, "Format Invalid"); got != want {
+				t.Errorf("%s: expected %q, got %q", f, want, got)
+			}
+		}
+	})
+
+	t.Run("conversion errors", func(t *testing.T) {
+		_, e := Convert("x").Int()
+		if got := Fmt("%+v", e); !Contains(got, "caller.debug_test.go:44") {
+			t.Errorf("expected conversion call site, got %q", got)
+		}
+	})
+}
+
+func TestRecoverStackDebug(t *testing.T) {
+	err := Try(func() { panic("boom") })
+	if got := Fmt("%+v", err); !Contains(got, "caller.debug_test.go:52") {
+		t.Errorf("expected panic site in stack, got %q", got)
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !fmtdebug
+
+package fmt
+
+// errCaller records where an error was created. It is empty in release
+// builds so errors carry no extra data; build with -tags fmtdebug to
+// capture the call stack (see caller.debug.go).
+type errCaller struct{}
+
+func captureCaller() errCaller { return errCaller{} }
+
+// String returns the captured frames, always "" in release builds
+func (errCaller) String() string { return "" }
@@ -0,0 +1,15 @@
+//go:build !fmtdebug
+
+package fmt
+
+import "testing"
+
+func TestCallerRelease(t *testing.T) {
+	err := Err(D.Format, D.Invalid)
+	if got := Fmt("%+v", err); got != "Format Invalid" {
+		t.Errorf("expected no stack in release builds, got %q", got)
+	}
+	if got := Fmt("%v", err); got != "Format Invalid" {
+		t.Errorf("unexpected %%v output %q", got)
+	}
+}
@@ -0,0 +1,111 @@
+package fmt
+
+import "io"
+
+// Message patterns for Classify, lowercase, checked in this order
+var classifyPatterns = []typePatterns{
+	{Msg.Timeout, [][]byte{
+		[]byte("timeout"), []byte("timed out"), []byte("deadline exceeded"),
+	}, nil},
+	{Msg.Auth, [][]byte{
+		[]byte("unauthorized"), []byte("forbidden"), []byte("unauthenticated"),
+		[]byte("status 401"), []byte("status 403"), []byte("code 401"), []byte("code 403"),
+	}, nil},
+	{Msg.Parse, [][]byte{
+		[]byte("invalid character"), []byte("unexpected end of json"),
+		[]byte("cannot unmarshal"), []byte("unexpected eof"), []byte("syntax error"),
+		[]byte("malformed"),
+	}, nil},
+	{Msg.Connect, [][]byte{
+		[]byte("connection refused"), []byte("connection reset"), []byte("connection closed"),
+		[]byte("no such host"), []byte("broken pipe"), []byte("network is unreachable"),
+	}, [][]byte{
+		[]byte("eof"), // Whole word only: not "thereof"
+	}},
+}
+
+// Classify returns the MessageType that describes err, so network failures
+// can be reported with the right type (see IsNetworkError):
+//
+//	Msg.Timeout  errors with Timeout() true (net.Error, context.DeadlineExceeded),
+//	             HTTP 408/504, "timeout", "deadline exceeded"
+//	Msg.Connect  io.EOF, "connection refused", "connection reset", "no such host",
+//	             HTTP 502/503
+//	Msg.Auth     HTTP 401/403 (StatusCode() int method), "unauthorized", "forbidden"
+//	Msg.Parse    io.ErrUnexpectedEOF, ErrSyntax, JSON decode messages
+//	             ("invalid character", "unexpected end of JSON input"...)
+//
+// Errors carrying their own type (a Type() MessageType method other than
+// Error) keep it. The whole chain is inspected (see Is); typed values are
+// checked before message text. Any other error is Msg.Error, nil is Msg.Normal.
+func Classify(err error) MessageType {
+	if err == nil {
+		return Msg.Normal
+	}
+	if t := classifyChain(err); t != Msg.Normal {
+		return t
+	}
+
+	c := GetConv()
+	defer c.putConv()
+	c.WrString(BuffOut, err.Error())
+	c.changeCase(true, BuffOut)
+	for _, cp := range classifyPatterns {
+		if c.bufferContainsPattern(BuffOut, cp.patterns) || c.bufferContainsWord(BuffOut, cp.words) {
+			return cp.t
+		}
+	}
+	return Msg.Error
+}
+
+// classifyChain returns the type found in the values of err's chain, or Msg.Normal
+func classifyChain(err error) MessageType {
+	for err != nil {
+		if t := classifyValue(err); t != Msg.Normal {
+			return t
+		}
+		switch u := err.(type) {
+		case interface{ Unwrap() error }:
+			err = u.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, e := range u.Unwrap() {
+				if t := classifyChain(e); t != Msg.Normal {
+					return t
+				}
+			}
+			return Msg.Normal
+		default:
+			return Msg.Normal
+		}
+	}
+	return Msg.Normal
+}
+
+// classifyValue classifies a single error from its value, type and methods
+func classifyValue(err error) MessageType {
+	switch {
+	case sameError(err, io.EOF):
+		return Msg.Connect
+	case sameError(err, io.ErrUnexpectedEOF), sameError(err, ErrSyntax):
+		return Msg.Parse
+	}
+	if t, ok := err.(interface{ Type() MessageType }); ok {
+		if mt := t.Type(); mt != Msg.Error && mt != Msg.Normal {
+			return mt
+		}
+	}
+	if t, ok := err.(interface{ Timeout() bool }); ok && t.Timeout() {
+		return Msg.Timeout
+	}
+	if s, ok := err.(interface{ StatusCode() int }); ok {
+		switch s.StatusCode() {
+		case 401, 403:
+			return Msg.Auth
+		case 408, 504:
+			return Msg.Timeout
+		case 502, 503:
+			return Msg.Connect
+		}
+	}
+	return Msg.Normal
+}
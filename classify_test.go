@@ -0,0 +1,65 @@
+package fmt
+
+import (
+	"io"
+	"testing"
+)
+
+type testTimeoutError struct{}
+
+func (testTimeoutError) Error() string   { return "i/o wait" }
+func (testTimeoutError) Timeout() bool   { return true }
+func (testTimeoutError) Temporary() bool { return true }
+
+type testStatusError struct{ code int }
+
+func (e testStatusError) Error() string   { return "http status" }
+func (e testStatusError) StatusCode() int { return e.code }
+
+type testTypedError struct{ t MessageType }
+
+func (e testTypedError) Error() string     { return "typed" }
+func (e testTypedError) Type() MessageType { return e.t }
+
+func TestClassify(t *testing.T) {
+	var list Errors
+	list.Add("stream", io.EOF)
+
+	tests := []struct {
+		name string
+		err  error
+		want MessageType
+	}{
+		{"nil", nil, Msg.Normal},
+		{"plain", Err("something broke"), Msg.Error},
+		{"Timeout method", testTimeoutError{}, Msg.Timeout},
+		{"wrapped timeout", Errf("fetch: %w", testTimeoutError{}), Msg.Timeout},
+		{"deadline text", Err("context deadline exceeded"), Msg.Timeout},
+		{"refused", Err("dial tcp 127.0.0.1:80: connect: connection refused"), Msg.Connect},
+		{"EOF", io.EOF, Msg.Connect},
+		{"wrapped EOF", Errf("read body: %w", io.EOF), Msg.Connect},
+		{"EOF text", Err("read tcp: EOF"), Msg.Connect},
+		{"eof inside word", Err("the cause thereof is unknown"), Msg.Error},
+		{"list member", list.Err(), Msg.Connect},
+		{"401", testStatusError{401}, Msg.Auth},
+		{"403 wrapped", Errf("api: %w", testStatusError{403}), Msg.Auth},
+		{"504", testStatusError{504}, Msg.Timeout},
+		{"503", testStatusError{503}, Msg.Connect},
+		{"500", testStatusError{500}, Msg.Error},
+		{"unauthorized text", Err("401 Unauthorized"), Msg.Auth},
+		{"json syntax", Err("invalid character 'x' looking for beginning of value"), Msg.Parse},
+		{"json truncated", Err("unexpected end of JSON input"), Msg.Parse},
+		{"unexpected EOF", io.ErrUnexpectedEOF, Msg.Parse},
+		{"conversion", func() error { _, err := Convert("12a").Int(); return err }(), Msg.Parse},
+		{"typed", testTypedError{Msg.Broadcast}, Msg.Broadcast},
+		{"typed generic", testTypedError{Msg.Error}, Msg.Error},
+	}
+	for _, tt := range tests {
+		if got := Classify(tt.err); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+	if !Classify(io.EOF).IsNetworkError() {
+		t.Error("expected EOF to be a network error")
+	}
+}
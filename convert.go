@@ -18,6 +18,19 @@ type Conv struct {
 
 	// ✅ OPTIMIZED MEMORY ARCHITECTURE - unsafe.Pointer for complex types
 	dataPtr unsafe.Pointer // Direct unsafe pointer to data (replaces ptrValue)
+
+	// Error record: the parts BuffErr was rendered from, kept to re-render it (see Code, In)
+	errArgs   []any   // LocStr parts and arguments, in order
+	errFormat string  // Errf format string, empty for Err
+	errLang   lang    // language the error was written in
+	wrapped   []error // errors wrapped by Err arguments or Errf %w (see Unwrap)
+
+	// Explicit message type set by Warn, Info, Success or SetType (see StringType)
+	msgType MessageType
+	typed   bool
+
+	// Pointers, maps and slices being written by JSON, to detect cycles
+	jsonRefs []jsonRef
 }
 
 // Convert initializes a new Conv struct with optional value for string,bool and number manipulation.
@@ -38,8 +51,8 @@ func Convert(v ...any) *Conv {
 		}
 
 		// Special case: error type should return immediately with error state
-		if _, isError := val.(error); isError {
-			return c.wrErr(val.(error).Error())
+		if errVal, isError := val.(error); isError {
+			return c.wrErr(errVal) // Wraps errVal (see Unwrap)
 		}
 
 		// Use AnyToBuff for ALL other conversions - eliminates all duplication
@@ -161,7 +174,7 @@ func (c *Conv) AnyToBuff(dest BuffDest, value any) {
 
 	// Special cases
 	case error:
-		c.wrErr(v.Error())
+		c.wrErr(v)
 
 	default:
 		// FIRST: Check if type implements String() method (fmt.Stringer interface)
@@ -195,7 +208,7 @@ func (c *Conv) AnyToBuff(dest BuffDest, value any) {
 		}
 
 		// Unknown/unsupported type - write error using DICTIONARY (REUSE existing wrErr)
-		c.wrErr(D.Type, D.Not, D.Supported)
+		c.wrErrKind(ErrUnsupportedType, D.Type, D.Not, D.Supported)
 	}
 }
 
@@ -6,6 +6,12 @@ package fmt
 // By using an anonymous struct, we define and initialize the dictionary in a single step,
 // avoiding the need for a separate 'type dictionary struct' declaration.
 // The usage API (e.g., D.Argument) remains unchanged.
+//
+// Compatibility: the English text of each term is also the source of
+// Error.Code ("Not Found" => "not_found"), which API clients branch on.
+// Changing it, even to fix a typo, changes those codes and is a breaking
+// change; the other languages can be reworded freely. TestErrorCodeStable
+// pins the codes of the sentinels and common errors.
 var D = struct {
 	// A
 	All        LocStr // "all"
@@ -52,6 +58,7 @@ var D = struct {
 	Email   LocStr // "email"
 	Empty   LocStr // "empty"
 	End     LocStr // "end"
+	Error   LocStr // "error"
 	Example LocStr // "example"
 	Exceeds LocStr // "exceeds"
 	Execute LocStr // "execute"
@@ -120,6 +127,7 @@ var D = struct {
 
 	// P
 	Page       LocStr // "page"
+	Panic      LocStr // "panic"
 	Pointer    LocStr // "pointer"
 	Point      LocStr // "point"
 	Preparing  LocStr // "preparing"
@@ -144,10 +152,12 @@ var D = struct {
 	Status    LocStr // "status"
 	String    LocStr // "string"
 	Shortcuts LocStr // "shortcuts"
+	Success   LocStr // "success"
 	Supported LocStr // "supported"
 	Switch    LocStr // "switch"
 	Switching LocStr // "switching"
 	Sync      LocStr // "sync"
+	Syntax    LocStr // "syntax"
 	System    LocStr // "system"
 
 	// Translate
@@ -224,6 +234,7 @@ var D = struct {
 	LocStr{"Email", "Correo electrónico", "电子邮件", "ईमेल", "البريد الإلكتروني", "Email", "Email", "E-Mail", "Электронная почта"},
 	LocStr{"Empty", "Vacío", "空", "खाली", "فارغ", "Vazio", "Vide", "Leer", "Пустой"},
 	LocStr{"End", "Fin", "结束", "अंत", "نهاية", "Fim", "Fin", "Ende", "Конец"},
+	LocStr{"Error", "Error", "错误", "त्रुटि", "خطأ", "Erro", "Erreur", "Fehler", "Ошибка"},
 	LocStr{"Example", "Ejemplo", "例子", "उदाहरण", "مثال", "Exemplo", "Exemple", "Beispiel", "Пример"},
 	LocStr{"Exceeds", "Excede", "超过", "अधिक", "يتجاوز", "Excede", "Dépasse", "Überschreitet", "Превышает"},
 	LocStr{"Execute", "Ejecutar", "执行", "निष्पादित करें", "تنفيذ", "Executar", "Exécuter", "Ausführen", "Выполнить"},
@@ -292,6 +303,7 @@ var D = struct {
 
 	// P
 	LocStr{"Page", "Página", "页面", "पृष्ठ", "صفحة", "Página", "Page", "Seite", "Страница"},
+	LocStr{"Panic", "Pánico", "恐慌", "पैनिक", "ذعر", "Pânico", "Panique", "Panik", "Паника"},
 	LocStr{"Pointer", "Puntero", "指针", "पॉइंटर", "مؤشر", "Ponteiro", "Pointeur", "Zeiger", "Указатель"},
 	LocStr{"Point", "Punto", "点", "बिंदु", "نقطة", "Ponto", "Point", "Punkt", "Точка"},
 	LocStr{"Preparing", "Preparando", "准备", "तैयारी", "تحضير", "Preparando", "Préparation", "Vorbereitung", "Подготовка"},
@@ -316,10 +328,12 @@ var D = struct {
 	LocStr{"Status", "Estado", "状态", "स्थिति", "حالة", "Status", "Statut", "Status", "Статус"},
 	LocStr{"String", "Cadena", "字符串", "स्ट्रिंग", "سلسلة", "String", "Chaîne", "Zeichenkette", "Строка"},
 	LocStr{"Shortcuts", "Atajos", "快捷键", "शॉर्टकट्स", "اختصارات", "Atalhos", "Raccourcis", "Kurzbefehle", "Ярлыки"},
+	LocStr{"Success", "Éxito", "成功", "सफलता", "نجاح", "Sucesso", "Succès", "Erfolg", "Успех"},
 	LocStr{"Supported", "Soportado", "支持", "समर्थित", "مدعوم", "Suportado", "Pris en charge", "Unterstützt", "Поддерживается"},
 	LocStr{"Switch", "Cambiar", "切换", "स्विच", "تبديل", "Mudar", "Changer", "Wechseln", "Переключить"},
 	LocStr{"Switching", "Cambiando", "切换中", "स्विच कर रहा है", "تبديل", "Mudando", "Changement", "Wechseln", "Переключение"},
 	LocStr{"Sync", "Sincronización", "同步", "सिंक", "مزامنة", "Sincronização", "Synchronisation", "Synchronisierung", "Синхронизация"},
+	LocStr{"Syntax", "Sintaxis", "语法", "सिंटैक्स", "صيغة", "Sintaxe", "Syntaxe", "Syntax", "Синтаксис"},
 	LocStr{"System", "Sistema", "系统", "सिस्टम", "نظام", "Sistema", "Système", "System", "Система"},
 
 	// T
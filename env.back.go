@@ -6,13 +6,23 @@ import (
 	"os"
 )
 
-// getSystemLang detects system language from environment variables
+// getSystemLang detects system language from environment variables, in the
+// gettext order: the LANGUAGE priority list ("fr:de"), then LC_ALL,
+// LC_MESSAGES and LANG. The first one naming a supported language wins.
 func (c *Conv) getSystemLang() lang {
-	// Use the centralized parser with common environment variables.
 	return c.langParser(
-		os.Getenv("LANG"),
 		os.Getenv("LANGUAGE"),
 		os.Getenv("LC_ALL"),
 		os.Getenv("LC_MESSAGES"),
+		os.Getenv("LANG"),
 	)
 }
+
+// detectColor enables ANSI styling unless NO_COLOR is set (https://no-color.org)
+// or the terminal declares no support (TERM=dumb)
+func detectColor() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return os.Getenv("TERM") != "dumb"
+}
@@ -0,0 +1,30 @@
+//go:build !wasm
+
+package fmt
+
+import "testing"
+
+func TestSystemLangOrder(t *testing.T) {
+	tests := []struct {
+		name                              string
+		language, lcAll, lcMessages, lang string
+		want                              lang
+	}{
+		{"LANGUAGE list wins over LANG", "fr:de", "", "", "en_US.UTF-8", FR},
+		{"LANGUAGE skips unsupported", "xx:de", "", "", "en_US.UTF-8", DE},
+		{"LC_ALL wins over LANG", "", "de_DE.UTF-8", "", "en_US.UTF-8", DE},
+		{"LC_ALL wins over LC_MESSAGES", "", "pt_BR.UTF-8", "es_ES.UTF-8", "en_US.UTF-8", PT},
+		{"LC_MESSAGES wins over LANG", "", "", "es_ES.UTF-8", "en_US.UTF-8", ES},
+		{"LANG alone", "", "", "", "ru_RU.UTF-8", RU},
+		{"C locale falls through", "", "C", "", "fr_FR.UTF-8", FR},
+	}
+	for _, tt := range tests {
+		t.Setenv("LANGUAGE", tt.language)
+		t.Setenv("LC_ALL", tt.lcAll)
+		t.Setenv("LC_MESSAGES", tt.lcMessages)
+		t.Setenv("LANG", tt.lang)
+		if got := (&Conv{}).getSystemLang(); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
@@ -6,7 +6,8 @@ import (
 	"syscall/js"
 )
 
-// getSystemLang detects browser language from navigator.language
+// getSystemLang detects browser language from navigator.languages,
+// falling back to navigator.language on older browsers
 func (c *Conv) getSystemLang() lang {
 	// Get browser language
 	navigator := js.Global().Get("navigator")
@@ -14,11 +15,26 @@ func (c *Conv) getSystemLang() lang {
 		return EN
 	}
 
+	// navigator.languages is ordered by user preference
+	var prefs []string
+	languages := navigator.Get("languages")
+	if !languages.IsUndefined() && !languages.IsNull() {
+		for i := 0; i < languages.Length(); i++ {
+			prefs = append(prefs, languages.Index(i).String())
+		}
+	}
+
 	language := navigator.Get("language")
-	if language.IsUndefined() {
-		return EN
+	if !language.IsUndefined() {
+		prefs = append(prefs, language.String())
 	}
 
 	// Use the centralized parser.
-	return c.langParser(language.String())
+	return c.langParser(prefs...)
+}
+
+// detectColor disables ANSI styling in the browser: the console shows message
+// types through its own levels (see ConsoleSink)
+func detectColor() bool {
+	return false
 }
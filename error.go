@@ -3,6 +3,23 @@ package fmt
 // Custom error messages to avoid importing standard library packages like "errors" or "fmt"
 // This keeps the binary size minimal for embedded systems and WebAssembly
 
+// Error is the immutable error value returned by Err, Errf, Join and the
+// conversion methods (Int, Float64, Bool, StringErr...).
+//
+// It is not a pooled Conv: it owns no buffers, so it can be compared, stored
+// long-term and passed between goroutines safely. It keeps the text rendered
+// at creation plus the parts it was built from (see Code and In).
+type Error struct {
+	msg     string      // text rendered at creation
+	args    []any       // LocStr parts and arguments, in order
+	format  string      // Errf format string, empty for Err
+	wrapped []error     // errors wrapped by Err arguments, Errf %w or Join
+	fields  []any       // key/value attributes added by With
+	caller  errCaller   // creation stack, only captured with -tags fmtdebug
+	msgType MessageType // Msg.Error unless created with a more specific type
+	lang    lang        // language msg was rendered in, also used by With
+}
+
 // Err creates a new error message with support for multilingual translations
 // Supports LocStr types for translations and lang types for language specification
 // eg:
@@ -10,22 +27,80 @@ package fmt
 // fmt.Err(D.Format, D.Invalid) returns "invalid format"
 // fmt.Err(ES,D.Format, D.Invalid) returns "formato inválido"
 
-func Err(msgs ...any) *Conv {
+func Err(msgs ...any) *Error {
 	// UNIFIED PROCESSING: Use same intermediate function as Translate() but write to BuffErr
-	return GetConv().SmartArgs(BuffErr, " ", true, false, msgs...)
+	return GetConv().SmartArgs(BuffErr, " ", true, false, msgs...).releaseErr()
 }
 
-// Errf creates a new Conv instance with error formatting similar to fmt.Errf
+// Errf creates a new error with formatting similar to fmt.Errorf
+// The %w verb formats an error like %v and wraps it (see Unwrap, Is and As).
 // Example: fmt.Errf("invalid value: %s", value).Error()
-func Errf(format string, args ...any) *Conv {
-	return GetConv().wrFormat(BuffErr, getCurrentLang(), format, args...)
+// Example: fmt.Errf("read config: %w", err)
+func Errf(format string, args ...any) *Error {
+	return GetConv().wrErrf(getCurrentLang(), format, args...).releaseErr()
+}
+
+// newError snapshots the error buffer and its recorded parts into an *Error.
+// The Conv keeps its state; use releaseErr when the Conv is no longer needed.
+func (c *Conv) newError() *Error {
+	t := Msg.Error
+	if c.typed && c.msgType != Msg.Normal {
+		t = c.msgType // e.g. Msg.Parse for JSON errors
+	}
+	return &Error{
+		msg:     c.GetString(BuffErr),
+		args:    c.errArgs,
+		format:  c.errFormat,
+		wrapped: c.wrapped,
+		caller:  captureCaller(),
+		msgType: t,
+		lang:    c.errLang,
+	}
+}
+
+// releaseErr returns the *Error built in c and releases c to the pool
+func (c *Conv) releaseErr() *Error {
+	e := c.newError()
+	c.putConv()
+	return e
+}
+
+// wrErrf formats into BuffOut and then moves the result to BuffErr.
+// Formatting directly into BuffErr would be read as a formatting failure
+// by wrFormat after the first verb.
+func (c *Conv) wrErrf(l lang, format string, args ...any) *Conv {
+	c.wrFormat(BuffOut, l, format, args...)
+	if !c.hasContent(BuffErr) {
+		wrapped := c.wrapped // Collected by %w, reset by the swap below
+		c.swapBuff(BuffOut, BuffErr)
+		c.recordErr(l, format, args)
+		c.wrapped = wrapped
+	}
+	return c
+}
+
+// recordErr appends the parts of the error being written to BuffErr in
+// language l. The slice is copied because callers may reuse their argument
+// slice. Without a format every error argument is wrapped; Errf wraps only
+// %w arguments.
+func (c *Conv) recordErr(l lang, format string, args []any) {
+	c.errLang = l
+	c.errFormat = format
+	c.errArgs = append(c.errArgs, args...)
+	if format == "" {
+		for _, arg := range args {
+			if e, ok := arg.(error); ok && e != nil {
+				c.wrapped = append(c.wrapped, e)
+			}
+		}
+	}
 }
 
 // StringErr returns the content of the Conv along with any error and auto-releases to pool
 func (c *Conv) StringErr() (out string, err error) {
-	// If there's an error, return empty string and the error object (do NOT release to pool)
+	// If there's an error, return empty string and an immutable copy of it
 	if c.hasContent(BuffErr) {
-		return "", c
+		return "", c.releaseErr()
 	}
 
 	// Otherwise return the string content and no error (safe to release to pool)
@@ -34,57 +109,58 @@ func (c *Conv) StringErr() (out string, err error) {
 	return out, nil
 }
 
-// wrErr writes error messages with support for int, string and LocStr
-// ENHANCED: Now supports int, string and LocStr parameters
+// wrErr writes error messages separated by spaces
+// LocStr parts are translated to the current language, strings and errors are
+// written as they are and any other value (numbers, bools, Stringers...) goes
+// through AnyToBuff, so Err(D.Invalid, D.Value, 3.5, true) keeps its values.
 // Used internally by AnyToBuff for type error messages
 func (c *Conv) wrErr(msgs ...any) *Conv {
+	l := getCurrentLang()
+	c.recordErr(l, "", msgs)
 	// Write messages using default language (no detection needed)
 	for i, msg := range msgs {
 		if i > 0 {
 			// Add space between words
 			c.WrString(BuffErr, " ")
 		}
-		// fmt.Printf("wrErr: Processing message part: %v\n", msg) // Depuración
 
 		switch v := msg.(type) {
 		case LocStr:
 			// Translate LocStr using default language
-			c.wrTranslation(v, getCurrentLang(), BuffErr)
+			c.wrTranslation(v, l, BuffErr)
+		case *LocStr:
+			if v != nil {
+				c.wrTranslation(*v, l, BuffErr)
+			}
 		case string:
 			// Direct string write
 			c.WrString(BuffErr, v)
-		case int:
-			// Convert int to string and write - simple conversion for errors
-			if v == 0 {
-				c.WrString(BuffErr, "0")
-			} else {
-				// Simple int to string conversion for error messages
-				var buf [20]byte // Enough for 64-bit int
-				n := len(buf)
-				negative := v < 0
-				if negative {
-					v = -v
-				}
-				for v > 0 {
-					n--
-					buf[n] = byte(v%10) + '0'
-					v /= 10
-				}
-				if negative {
-					n--
-					buf[n] = '-'
-				}
-				c.WrString(BuffErr, string(buf[n:]))
-			}
+		case error:
+			// Wrapped error: write its message (recordErr keeps the chain)
+			c.WrString(BuffErr, v.Error())
+		case nil:
+			c.WrString(BuffErr, "<nil>")
 		default:
-			// For other types, convert to string representation
-			c.WrString(BuffErr, "<unsupported>")
+			c.wrValue(BuffErr, v)
 		}
 	}
-	// fmt.Printf("wrErr: Final error buffer content: %q, errLen: %d\n", c.GetString(BuffErr), c.errLen) // Depuración
 	return c
 }
 
+// wrValue writes v converted by AnyToBuff. The conversion runs on a pooled Conv
+// because AnyToBuff resets the error buffer and type state of its receiver.
+// Values AnyToBuff cannot convert are written as "<unsupported>".
+func (c *Conv) wrValue(dest BuffDest, v any) {
+	tmp := GetConv()
+	tmp.AnyToBuff(BuffOut, v)
+	if tmp.hasContent(BuffErr) {
+		c.WrString(dest, "<unsupported>")
+	} else {
+		c.wrBytes(dest, tmp.getBytes(BuffOut))
+	}
+	tmp.putConv()
+}
+
 func (c *Conv) getError() string {
 	if !c.hasContent(BuffErr) { // ✅ Use API method instead of len(c.err)
 		return ""
@@ -92,6 +168,91 @@ func (c *Conv) getError() string {
 	return c.GetString(BuffErr) // ✅ Use API method instead of direct string(c.err)
 }
 
+// Error returns the error text of the chain, or "" when there is no error
 func (c *Conv) Error() string {
 	return c.getError()
 }
+
+// Error returns the text rendered when the error was created
+func (e *Error) Error() string {
+	return e.msg
+}
+
+// Code returns a stable, language-independent identifier for the error, built
+// from the English text of its dictionary terms in snake case.
+// Plain strings and values are not part of the code, so it does not change
+// with the arguments or the output language.
+// Returns "" when the error has no dictionary terms. The code follows the
+// English dictionary text, so rewording an English term changes it (see D).
+//
+//	Err(D.Format, D.Invalid).Code()          // "format_invalid"
+//	Err(ES, D.Number, D.Overflow, 42).Code() // "number_overflow"
+func (e *Error) Code() string {
+	return errCode(e.args)
+}
+
+// In renders the error again in another language. Accepts the same values as Lang.
+// Error() keeps returning the text rendered when the error was created.
+//
+//	err := Err(D.Format, D.Invalid)
+//	err.Error()   // "Format Invalid"
+//	err.In(ES)    // "Formato Inválido"
+//	err.In("fr")  // "Format Invalide"
+func (e *Error) In(l any) string {
+	if len(e.args) == 0 {
+		return e.msg // Nothing recorded: text written directly (e.g. Join)
+	}
+	scope := Lang(l).l
+	return renderErr(scope, e.format, e.args) + renderFields(scope, e.fields)
+}
+
+// renderErr renders recorded error parts in the given language
+func renderErr(l lang, format string, args []any) string {
+	r := GetConv()
+	if format != "" {
+		r.wrFormat(BuffOut, l, format, args...)
+	} else {
+		r.processTranslatedArgs(BuffOut, args, l, 0, " ", false, false)
+	}
+	return r.String()
+}
+
+// errCode builds the snake case code from the English text of the LocStr parts
+func errCode(args []any) string {
+	var code []byte
+	for _, arg := range args {
+		var en string
+		switch v := arg.(type) {
+		case LocStr:
+			en = v[EN]
+		case *LocStr:
+			if v != nil {
+				en = v[EN]
+			}
+		}
+		if en == "" {
+			continue
+		}
+		if len(code) > 0 {
+			code = append(code, '_')
+		}
+		sep := false
+		for i := 0; i < len(en); i++ {
+			ch := en[i]
+			switch {
+			case ch >= 'A' && ch <= 'Z':
+				ch += asciiCaseDiff
+			case (ch >= 'a' && ch <= 'z') || (ch >= '0' && ch <= '9'):
+			default:
+				sep = true
+				continue
+			}
+			if sep && len(code) > 0 && code[len(code)-1] != '_' {
+				code = append(code, '_')
+			}
+			sep = false
+			code = append(code, ch)
+		}
+	}
+	return string(code)
+}
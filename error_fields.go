@@ -0,0 +1,97 @@
+package fmt
+
+// With returns a copy of the error carrying the given key/value attributes.
+// Pairs are appended to the text as key=value, rendered with the same rules as
+// Translate in the language of the error (LocStr values are translated,
+// numbers and errors are converted), and stay available as raw values through
+// Fields for structured loggers.
+// Values containing spaces, quotes or '=' are quoted.
+//
+//	err := Err(D.Field, D.Invalid).With("field", "email", "value", 42)
+//	err.Error()  // "Field Invalid field=email value=42"
+//	err.Fields() // []any{"field", "email", "value", 42}
+//	err.Code()   // "field_invalid" (attributes are not part of the code)
+//
+// A trailing key without value gets an empty value. The original error is
+// not modified.
+func (e *Error) With(pairs ...any) *Error {
+	if len(pairs)%2 != 0 {
+		pairs = append(pairs[:len(pairs):len(pairs)], "")
+	}
+	n := *e
+	n.fields = append(e.fields[:len(e.fields):len(e.fields)], pairs...)
+	n.msg = e.msg + renderFields(e.lang, pairs)
+	return &n
+}
+
+// Fields returns the attributes added by With as alternating key/value pairs,
+// ready for loggers that take that form (e.g. slog's args ...any).
+// Returns nil when the error has no attributes.
+func (e *Error) Fields() []any {
+	if len(e.fields) == 0 {
+		return nil
+	}
+	return append([]any(nil), e.fields...)
+}
+
+// Field returns the value of the first attribute with the given key
+func (e *Error) Field(key string) (any, bool) {
+	for i := 0; i+1 < len(e.fields); i += 2 {
+		if k, ok := e.fields[i].(string); ok && k == key {
+			return e.fields[i+1], true
+		}
+	}
+	return nil, false
+}
+
+// renderFields renders key/value pairs as " key=value key=value" in the given language
+func renderFields(l lang, pairs []any) string {
+	if len(pairs) == 0 {
+		return ""
+	}
+	c := GetConv()
+	v := GetConv()
+	for i := 0; i+1 < len(pairs); i += 2 {
+		c.wrByte(BuffOut, ' ')
+		c.processTranslatedArgs(BuffOut, pairs[i:i+1], l, 0, "", false, false)
+		c.wrByte(BuffOut, '=')
+
+		v.ResetBuffer(BuffOut)
+		v.processTranslatedArgs(BuffOut, pairs[i+1:i+2], l, 0, "", false, false)
+		c.wrFieldValue(v.getBytes(BuffOut))
+	}
+	v.putConv()
+	return c.String()
+}
+
+// wrFieldValue writes a field value to BuffOut, quoted when it would be ambiguous
+func (c *Conv) wrFieldValue(val []byte) {
+	quote := len(val) == 0
+	for _, ch := range val {
+		if ch == ' ' || ch == '"' || ch == '=' || ch == '\\' || ch < ' ' {
+			quote = true
+			break
+		}
+	}
+	if !quote {
+		c.wrBytes(BuffOut, val)
+		return
+	}
+	c.wrByte(BuffOut, '"')
+	for _, ch := range val {
+		switch ch {
+		case '"', '\\':
+			c.wrByte(BuffOut, '\\')
+			c.wrByte(BuffOut, ch)
+		case '\n':
+			c.WrString(BuffOut, `\n`)
+		case '\t':
+			c.WrString(BuffOut, `\t`)
+		case '\r':
+			c.WrString(BuffOut, `\r`)
+		default:
+			c.wrByte(BuffOut, ch)
+		}
+	}
+	c.wrByte(BuffOut, '"')
+}
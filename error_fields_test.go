@@ -0,0 +1,94 @@
+package fmt
+
+import "testing"
+
+func TestErrorWith(t *testing.T) {
+	OutLang(EN)
+	defer OutLang(EN)
+
+	base := Err(D.Field, D.Invalid)
+	err := base.With("field", "email", "value", 42)
+
+	if got := err.Error(); got != "Field Invalid field=email value=42" {
+		t.Errorf("unexpected message %q", got)
+	}
+	if got := base.Error(); got != "Field Invalid" {
+		t.Errorf("With must not modify the original error, got %q", got)
+	}
+	if got := err.Code(); got != "field_invalid" {
+		t.Errorf("expected code 'field_invalid', got %q", got)
+	}
+
+	fields := err.Fields()
+	if len(fields) != 4 || fields[0] != "field" || fields[1] != "email" || fields[2] != "value" || fields[3] != 42 {
+		t.Errorf("unexpected fields %v", fields)
+	}
+	if v, ok := err.Field("value"); !ok || v != 42 {
+		t.Errorf("expected value 42, got %v %v", v, ok)
+	}
+	if _, ok := err.Field("missing"); ok {
+		t.Error("expected missing field not to be found")
+	}
+	if base.Fields() != nil {
+		t.Error("expected no fields on the original error")
+	}
+
+	t.Run("values rendered like Translate", func(t *testing.T) {
+		e := Err("bad").With("ok", true, "ratio", 0.5, "reason", D.Empty, "cause", Err("x"))
+		if got := e.Error(); got != "bad ok=true ratio=0.5 reason=Empty cause=x" {
+			t.Errorf("unexpected message %q", got)
+		}
+	})
+
+	t.Run("quoting", func(t *testing.T) {
+		e := Err("bad").With("name", "John Doe", "q", `a"b`, "empty", "", "odd")
+		want := `bad name="John Doe" q="a\"b" empty="" odd=""`
+		if got := e.Error(); got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("chained With", func(t *testing.T) {
+		e := base.With("a", 1).With("b", 2)
+		if got := e.Error(); got != "Field Invalid a=1 b=2" {
+			t.Errorf("unexpected message %q", got)
+		}
+		if len(e.Fields()) != 4 {
+			t.Errorf("expected 4 field entries, got %v", e.Fields())
+		}
+	})
+
+	t.Run("In", func(t *testing.T) {
+		e := base.With("reason", D.Empty)
+		if got := e.In(ES); got != "Campo Inválido reason=Vacío" {
+			t.Errorf("unexpected translation %q", got)
+		}
+	})
+
+	t.Run("error language", func(t *testing.T) {
+		// Fields follow the language of the error, not the global one
+		want := "Campo Inválido valor=Vacío"
+		if got := Lang(ES).Err(D.Field, D.Invalid).With("valor", D.Empty).Error(); got != want {
+			t.Errorf("Lang(ES): expected %q, got %q", want, got)
+		}
+		if got := Err(ES, D.Field, D.Invalid).With("valor", D.Empty).Error(); got != want {
+			t.Errorf("Err(ES): expected %q, got %q", want, got)
+		}
+		if got := Lang(ES).Errf("%L", D.Empty).With("k", D.Empty).Error(); got != "Vacío k=Vacío" {
+			t.Errorf("Lang(ES).Errf: unexpected message %q", got)
+		}
+		OutLang(ES)
+		defer OutLang(EN)
+		if got := Lang(EN).Err(D.Field, D.Invalid).With("value", D.Empty).Error(); got != "Field Invalid value=Empty" {
+			t.Errorf("Lang(EN) under OutLang(ES): unexpected message %q", got)
+		}
+	})
+
+	t.Run("wrapping kept", func(t *testing.T) {
+		_, conv := Convert("x").Int()
+		e := conv.(*Error).With("input", "x")
+		if !Is(e, ErrSyntax) {
+			t.Error("expected With to keep wrapped errors")
+		}
+	})
+}
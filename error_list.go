@@ -0,0 +1,123 @@
+package fmt
+
+// Errors accumulates translated errors per field, e.g. while validating a form.
+// The zero value is ready to use. It is not safe for concurrent use.
+//
+//	var errs Errors
+//	errs.Add("email", D.Email, D.Invalid)
+//	errs.Add("name", D.Field, D.Empty)
+//	if err := errs.Err(); err != nil {
+//		err.Error()  // "email: Email Invalid\nname: Field Empty"
+//		errs.Map()   // map[email:Email Invalid name:Field Empty]
+//		errs.Map(ES) // map[email:Correo Inválido name:Campo Vacío]
+//	}
+//
+// Is and As look through every member, so sentinels such as ErrSyntax are
+// found in whichever field produced them.
+type Errors struct {
+	list []fieldError
+}
+
+// fieldError is a member of Errors
+type fieldError struct {
+	field string // empty for errors not tied to a field
+	err   *Error
+}
+
+// Add appends an error for field built from msgs like Err.
+// An error argument is wrapped (see Unwrap), so Add("age", err) keeps err matchable.
+// Returns the list for chaining.
+func (es *Errors) Add(field string, msgs ...any) *Errors {
+	es.list = append(es.list, fieldError{field: field, err: Err(msgs...)})
+	return es
+}
+
+// Len returns the number of accumulated errors
+func (es *Errors) Len() int {
+	return len(es.list)
+}
+
+// Err returns the list as an error, or nil when nothing was added.
+// Use it as the return value to avoid a non-nil error holding an empty list.
+func (es *Errors) Err() error {
+	if es == nil || len(es.list) == 0 {
+		return nil
+	}
+	return es
+}
+
+// Error returns one "field: message" line per error, in the order they were added
+func (es *Errors) Error() string {
+	return es.render(nil)
+}
+
+// In renders the list like Error in another language. Accepts the same values as Lang.
+func (es *Errors) In(l any) string {
+	return es.render(&l)
+}
+
+// Map returns the messages per field. Several errors on the same field are
+// joined with ", ". An optional language renders them in that language.
+// Returns nil when the list is empty.
+func (es *Errors) Map(l ...any) map[string]string {
+	if len(es.list) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(es.list))
+	for _, fe := range es.list {
+		msg := fe.err.Error()
+		if len(l) > 0 {
+			msg = fe.err.In(l[0])
+		}
+		if prev, ok := m[fe.field]; ok {
+			msg = prev + ", " + msg
+		}
+		m[fe.field] = msg
+	}
+	return m
+}
+
+// Unwrap returns the member errors so Is and As inspect every field
+func (es *Errors) Unwrap() []error {
+	if len(es.list) == 0 {
+		return nil
+	}
+	out := make([]error, len(es.list))
+	for i, fe := range es.list {
+		out[i] = fe.err
+	}
+	return out
+}
+
+// Type returns Msg.Error when the list holds errors and Msg.Normal when it is empty
+func (es *Errors) Type() MessageType {
+	if len(es.list) == 0 {
+		return Msg.Normal
+	}
+	return Msg.Error
+}
+
+// StringType returns the joined message and its MessageType, like Conv.StringType
+func (es *Errors) StringType() (string, MessageType) {
+	return es.Error(), es.Type()
+}
+
+// render writes one line per error, translated when l is not nil
+func (es *Errors) render(l *any) string {
+	c := GetConv()
+	for i, fe := range es.list {
+		if i > 0 {
+			c.wrByte(BuffOut, '\n')
+		}
+		if fe.field != "" {
+			c.WrString(BuffOut, fe.field)
+			c.WrString(BuffOut, ": ")
+		}
+		if l != nil {
+			c.WrString(BuffOut, fe.err.In(*l))
+		} else {
+			c.WrString(BuffOut, fe.err.Error())
+		}
+	}
+	return c.String()
+}
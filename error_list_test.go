@@ -0,0 +1,68 @@
+package fmt
+
+import "testing"
+
+func TestErrorsList(t *testing.T) {
+	OutLang(EN)
+	defer OutLang(EN)
+
+	var errs Errors
+	if errs.Err() != nil || errs.Len() != 0 || errs.Map() != nil {
+		t.Fatal("expected empty list to report no error")
+	}
+	if _, mt := errs.StringType(); mt != Msg.Normal {
+		t.Errorf("expected Msg.Normal for empty list, got %v", mt)
+	}
+
+	errs.Add("email", D.Email, D.Invalid).Add("name", D.Field, D.Empty)
+	_, conv := Convert("12a").Int()
+	errs.Add("age", conv)
+	errs.Add("email", D.Required)
+
+	err := errs.Err()
+	if err == nil || errs.Len() != 4 {
+		t.Fatalf("expected 4 errors, got %d", errs.Len())
+	}
+
+	want := "email: Email Invalid\nname: Field Empty\nage: Format Invalid\nemail: Required"
+	if got := err.Error(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	m := errs.Map()
+	if m["email"] != "Email Invalid, Required" || m["name"] != "Field Empty" || m["age"] != "Format Invalid" {
+		t.Errorf("unexpected map %v", m)
+	}
+
+	es := errs.Map(ES)
+	if es["name"] != "Campo Vacío" {
+		t.Errorf("expected Spanish message, got %q", es["name"])
+	}
+	if got := errs.In(ES); got[:len("email: Correo")] != "email: Correo" {
+		t.Errorf("unexpected Spanish rendering %q", got)
+	}
+
+	t.Run("Is and As", func(t *testing.T) {
+		if !Is(err, ErrSyntax) {
+			t.Error("expected ErrSyntax from the age member")
+		}
+		if Is(err, ErrRange) {
+			t.Error("unexpected ErrRange match")
+		}
+		var e *Error
+		if !As(err, &e) || e.Code() != "email_invalid" {
+			t.Errorf("expected first member as *Error, got %v", e)
+		}
+		var list *Errors
+		if !As(error(Errf("form: %w", err)), &list) || list.Len() != 4 {
+			t.Error("expected As to find the list through a wrapper")
+		}
+	})
+
+	t.Run("MessageType", func(t *testing.T) {
+		msg, mt := errs.StringType()
+		if mt != Msg.Error || msg != want {
+			t.Errorf("expected Msg.Error with joined message, got %v %q", mt, msg)
+		}
+	})
+}
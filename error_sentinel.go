@@ -0,0 +1,46 @@
+package fmt
+
+// Sentinel errors attached to conversion failures. The returned error keeps its
+// translated text and wraps one of these, so callers can tell failures apart
+// with Is regardless of the output language:
+//
+//	_, err := Convert("12a").Int()
+//	Is(err, ErrSyntax) // true
+//	Is(err, ErrRange)  // false
+var (
+	// ErrSyntax reports a value that does not have the expected format
+	// (e.g. "12a" for Int, "maybe" for Bool or an empty string)
+	ErrSyntax = newSentinel(D.Syntax, D.Invalid)
+
+	// ErrRange reports a value out of range for the target type
+	// (e.g. "3000000000" for Int32 or "-1" for Uint)
+	ErrRange = newSentinel(D.Out, D.Of, D.Range)
+
+	// ErrUnsupportedType reports a value whose type cannot be converted
+	ErrUnsupportedType = newSentinel(D.Type, D.Not, D.Supported)
+
+	// ErrNotFound reports a missing delimiter or key (e.g. ExtractValue)
+	ErrNotFound = newSentinel(D.Not, D.Found)
+)
+
+// newSentinel builds a sentinel with its English text. It does not go through
+// Err: the conversion code that wraps sentinels is itself reached from Err.
+func newSentinel(terms ...LocStr) *Error {
+	e := &Error{args: make([]any, len(terms))}
+	for i, t := range terms {
+		if i > 0 {
+			e.msg += " "
+		}
+		e.msg += t[EN]
+		e.args[i] = t
+	}
+	return e
+}
+
+// wrErrKind writes the error like wrErr and wraps the sentinel kind
+// (ErrSyntax, ErrRange...) without adding its text to the message
+func (c *Conv) wrErrKind(kind *Error, msgs ...any) *Conv {
+	c.wrErr(msgs...)
+	c.wrapped = append(c.wrapped, kind)
+	return c
+}
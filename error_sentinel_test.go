@@ -0,0 +1,61 @@
+package fmt
+
+import "testing"
+
+func TestConversionSentinels(t *testing.T) {
+	extract := func(s string) error {
+		_, err := Convert(s).ExtractValue(":")
+		return err
+	}
+	tests := []struct {
+		name string
+		err  func() error
+		want error
+	}{
+		{"Int syntax", func() error { _, err := Convert("12a").Int(); return err }, ErrSyntax},
+		{"Int float syntax", func() error { _, err := Convert("3.x").Int(); return err }, ErrSyntax},
+		{"Int32 range", func() error { _, err := Convert("3000000000").Int32(); return err }, ErrRange},
+		{"Uint negative", func() error { _, err := Convert("-1").Uint(); return err }, ErrRange},
+		{"Float64 syntax", func() error { _, err := Convert("1.2.3").Float64(); return err }, ErrSyntax},
+		{"Float64 empty", func() error { _, err := Convert("").Float64(); return err }, ErrSyntax},
+		{"Bool syntax", func() error { _, err := Convert("maybe").Bool(); return err }, ErrSyntax},
+		{"unsupported type", func() error { _, err := Convert(struct{}{}).Int(); return err }, ErrUnsupportedType},
+		{"ExtractValue", func() error { return extract("no delimiter") }, ErrNotFound},
+	}
+	sentinels := []error{ErrSyntax, ErrRange, ErrUnsupportedType, ErrNotFound}
+	for _, tt := range tests {
+		err := tt.err()
+		if err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+			continue
+		}
+		for _, s := range sentinels {
+			if got := Is(err, s); got != (s == tt.want) {
+				t.Errorf("%s: Is(%q, %q) = %v", tt.name, err.Error(), s.Error(), got)
+			}
+		}
+	}
+}
+
+func TestSentinelText(t *testing.T) {
+	OutLang(ES)
+	defer OutLang(EN)
+
+	_, err := Convert("12a").Int()
+	// The message keeps the translated text, not the sentinel text
+	if got := err.Error(); got != "Formato Inválido" {
+		t.Errorf("expected 'Formato Inválido', got %q", got)
+	}
+	if !Is(err, ErrSyntax) {
+		t.Error("expected ErrSyntax in any language")
+	}
+	if got := ErrRange.Error(); got != "Out of Range" {
+		t.Errorf("expected English sentinel text, got %q", got)
+	}
+	if got := ErrSyntax.In(ES); got != "Sintaxis Inválido" {
+		t.Errorf("expected 'Sintaxis Inválido', got %q", got)
+	}
+	if got := ErrNotFound.Code(); got != "not_found" {
+		t.Errorf("expected code 'not_found', got %q", got)
+	}
+}
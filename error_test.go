@@ -0,0 +1,197 @@
+package fmt
+
+import "testing"
+
+func TestErrfArguments(t *testing.T) {
+	got := Errf("invalid value: %s at position %d", "abc", 5).Error()
+	if got != "invalid value: abc at position 5" {
+		t.Errorf("unexpected Errf output %q", got)
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *Error
+		want string
+	}{
+		{"dictionary terms", Err(D.Format, D.Invalid), "format_invalid"},
+		{"language independent", Err(ES, D.Format, D.Invalid), "format_invalid"},
+		{"arguments ignored", Err(D.Number, D.Overflow, 42, "x"), "number_overflow"},
+		{"multi-word terms", Err(D.NonNumeric, D.Value), "non_numeric_value"},
+		{"pointer terms", Err(&D.Empty, &D.String), "empty_string"},
+		{"Errf %L args", Errf("%L %L: %d", D.Value, D.Invalid, 3), "value_invalid"},
+		{"plain text", Err("boom"), ""},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Code(); got != tt.want {
+			t.Errorf("%s: expected code %q, got %q", tt.name, tt.want, got)
+		}
+	}
+
+	_, err := Convert("abc").Int()
+	if got := err.(*Error).Code(); got != "format_invalid" {
+		t.Errorf("expected conversion error code 'format_invalid', got %q", got)
+	}
+}
+
+// TestErrorCodeStable pins codes clients may branch on. A failure here means an
+// English dictionary term was reworded: that breaks those clients (see D).
+func TestErrorCodeStable(t *testing.T) {
+	_, rangeErr := Convert("99999999999").Int32()
+	_, boolErr := Convert("x").Bool()
+	tests := []struct {
+		err  *Error
+		want string
+	}{
+		{ErrSyntax, "syntax_invalid"},
+		{ErrRange, "out_of_range"},
+		{ErrUnsupportedType, "type_not_supported"},
+		{ErrNotFound, "not_found"},
+		{rangeErr.(*Error), "number_overflow"},
+		{boolErr.(*Error), "value_invalid"},
+		{Err(D.Field, D.Invalid), "field_invalid"},
+		{Err(D.Required, D.Field), "required_field"},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Code(); got != tt.want {
+			t.Errorf("%q: expected code %q, got %q", tt.err.Error(), tt.want, got)
+		}
+	}
+}
+
+func TestErrorIn(t *testing.T) {
+	OutLang(EN)
+	defer OutLang(EN)
+
+	err := Err(D.Format, D.Invalid)
+	if got := err.Error(); got != "Format Invalid" {
+		t.Errorf("expected 'Format Invalid', got %q", got)
+	}
+	if got := err.In(ES); got != "Formato Inválido" {
+		t.Errorf("expected 'Formato Inválido', got %q", got)
+	}
+	if got := err.In("fr"); got != "Format Invalide" {
+		t.Errorf("expected 'Format Invalide', got %q", got)
+	}
+	// Rendering in another language does not change the original text
+	if got := err.Error(); got != "Format Invalid" {
+		t.Errorf("expected original text kept, got %q", got)
+	}
+
+	t.Run("arguments kept", func(t *testing.T) {
+		e := Err(D.Value, D.Invalid, 42)
+		if got := e.In(DE); got != "Wert Ungültig 42" {
+			t.Errorf("expected 'Wert Ungültig 42', got %q", got)
+		}
+	})
+
+	t.Run("Errf", func(t *testing.T) {
+		e := Errf("%L: %d", D.Value, 7)
+		if got := e.In(ES); got != "Valor: 7" {
+			t.Errorf("expected 'Valor: 7', got %q", got)
+		}
+	})
+
+	t.Run("scoped error", func(t *testing.T) {
+		e := Lang(ES).Err(D.Empty, D.String)
+		if got := e.Error(); got != "Vacío Cadena" {
+			t.Errorf("expected 'Vacío Cadena', got %q", got)
+		}
+		if got := e.In(EN); got != "Empty String" {
+			t.Errorf("expected 'Empty String', got %q", got)
+		}
+	})
+
+	t.Run("conversion errors", func(t *testing.T) {
+		_, e := Convert("x").Int()
+		if got := e.(*Error).In(ES); got != "Formato Inválido" {
+			t.Errorf("expected 'Formato Inválido', got %q", got)
+		}
+	})
+}
+
+func TestErrorNotPooled(t *testing.T) {
+	_, err := Convert("abc").Int()
+	if _, ok := err.(*Error); !ok {
+		t.Fatalf("expected *Error, got %T", err)
+	}
+	want := err.Error()
+
+	// Reusing pooled converters must not change an error already returned
+	for i := 0; i < 10; i++ {
+		_, _ = Convert("1.5x").Float64()
+		_ = Convert("ok").String()
+		_ = Err(D.Number, D.Overflow).Error()
+	}
+	if got := err.Error(); got != want {
+		t.Errorf("error changed after pool reuse: %q => %q", want, got)
+	}
+
+	t.Run("StringErr", func(t *testing.T) {
+		_, e := Convert(Err(D.Empty, D.String)).StringErr()
+		if _, ok := e.(*Error); !ok {
+			t.Fatalf("expected *Error, got %T", e)
+		}
+		_ = Convert("reused").String()
+		if got := e.Error(); got != "Empty String" {
+			t.Errorf("expected 'Empty String', got %q", got)
+		}
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		shared := Err(D.Format, D.Invalid)
+		done := make(chan bool)
+		for i := 0; i < 8; i++ {
+			go func() {
+				for j := 0; j < 100; j++ {
+					_, _ = Convert("x").Int()
+					if shared.Error() != "Format Invalid" || shared.Code() != "format_invalid" {
+						done <- false
+						return
+					}
+				}
+				done <- true
+			}()
+		}
+		for i := 0; i < 8; i++ {
+			if !<-done {
+				t.Error("shared error changed while converters were reused")
+			}
+		}
+	})
+}
+
+type testID uint32
+
+func TestErrArgumentTypes(t *testing.T) {
+	OutLang(EN)
+	defer OutLang(EN)
+
+	var nilStr *LocStr
+	tests := []struct {
+		name string
+		err  *Conv
+		want string
+	}{
+		{"float and bool", GetConv().wrErr(D.Invalid, D.Value, 3.5, true), "Invalid Value 3.5 true"},
+		{"unsigned", GetConv().wrErr(uint8(7), uint64(18446744073709551615)), "7 18446744073709551615"},
+		{"negative ints", GetConv().wrErr(-42, int64(-9223372036854775808)), "-42 -9223372036854775808"},
+		{"custom numeric type", GetConv().wrErr(D.Value, testID(12)), "Value 12"},
+		{"Stringer", GetConv().wrErr(testStringer{}), "stringer value"},
+		{"error", GetConv().wrErr(D.Failed, Err(D.Empty)), "Failed Empty"},
+		{"LocStr pointer", GetConv().wrErr(&D.Format, nilStr, D.Invalid), "Format  Invalid"},
+		{"nil", GetConv().wrErr("got", nil), "got <nil>"},
+		{"unsupported", GetConv().wrErr("got", struct{}{}), "got <unsupported>"},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.want, got)
+		}
+		tt.err.putConv()
+	}
+
+	if got := Convert(uint64(18446744073709551615)).String(); got != "18446744073709551615" {
+		t.Errorf("expected max uint64, got %q", got)
+	}
+}
@@ -0,0 +1,153 @@
+package fmt
+
+// =============================================================================
+// ERROR CHAINS - errors package equivalents: Unwrap, Is, As and Join
+// =============================================================================
+
+// Unwrap returns the errors wrapped by this error: error arguments passed to
+// Err, %w arguments of Errf and the members of Join. Returns nil when nothing
+// was wrapped.
+// Having the []error form makes the standard errors.Is and errors.As work too.
+func (e *Error) Unwrap() []error {
+	return e.wrapped
+}
+
+// Unwrap returns the error wrapped by err: the result of its Unwrap() error
+// method, or the only element of its Unwrap() []error method.
+// Returns nil otherwise, including for errors wrapping several errors.
+//
+//	inner := Err("disk full")
+//	Unwrap(Errf("save: %w", inner)) == inner
+func Unwrap(err error) error {
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		return u.Unwrap()
+	case interface{ Unwrap() []error }:
+		if list := u.Unwrap(); len(list) == 1 {
+			return list[0]
+		}
+	}
+	return nil
+}
+
+// Is reports whether any error in err's chain matches target, like errors.Is.
+// An error matches if it is equal to target or has an Is(error) bool method
+// returning true. The chain is followed through Unwrap() error and
+// Unwrap() []error (depth-first).
+//
+//	if Is(err, io.EOF) { ... }
+func Is(err, target error) bool {
+	if err == nil || target == nil {
+		return err == target
+	}
+	for {
+		if sameError(err, target) {
+			return true
+		}
+		if x, ok := err.(interface{ Is(error) bool }); ok && x.Is(target) {
+			return true
+		}
+		switch u := err.(type) {
+		case interface{ Unwrap() error }:
+			err = u.Unwrap()
+			if err == nil {
+				return false
+			}
+		case interface{ Unwrap() []error }:
+			for _, e := range u.Unwrap() {
+				if Is(e, target) {
+					return true
+				}
+			}
+			return false
+		default:
+			return false
+		}
+	}
+}
+
+// As finds the first error in err's chain that has type T, stores it in
+// target and returns true, like errors.As. T may be a concrete type or an
+// interface. Errors with an As(any) bool method are also asked.
+// Uses generics instead of reflection so it stays small under TinyGo.
+//
+//	var e *Error
+//	if As(err, &e) { code := e.Code() }
+func As[T any](err error, target *T) bool {
+	if err == nil || target == nil {
+		return false
+	}
+	for {
+		if v, ok := err.(T); ok {
+			*target = v
+			return true
+		}
+		if x, ok := err.(interface{ As(any) bool }); ok && x.As(target) {
+			return true
+		}
+		switch u := err.(type) {
+		case interface{ Unwrap() error }:
+			err = u.Unwrap()
+			if err == nil {
+				return false
+			}
+		case interface{ Unwrap() []error }:
+			for _, e := range u.Unwrap() {
+				if As(e, target) {
+					return true
+				}
+			}
+			return false
+		default:
+			return false
+		}
+	}
+}
+
+// Join returns an error wrapping the given errors, like errors.Join.
+// Nil errors are discarded; Join returns nil if every value is nil.
+// The message joins the member messages with newlines.
+func Join(errs ...error) error {
+	var c *Conv
+	var wrapped []error
+	for _, e := range errs {
+		if e == nil {
+			continue
+		}
+		if c == nil {
+			c = GetConv()
+		} else {
+			c.wrByte(BuffErr, '\n')
+		}
+		c.WrString(BuffErr, e.Error())
+		wrapped = append(wrapped, e)
+	}
+	if c == nil {
+		return nil
+	}
+	joined := c.releaseErr()
+	joined.wrapped = wrapped
+	return joined
+}
+
+// sameError reports whether a and b are the same error value, comparing with
+// == like errors.Is. *Error pointers compare directly; for other types a
+// panic from == (an uncomparable value, e.g. a slice inside an interface
+// field) reports false instead.
+func sameError(a, b error) bool {
+	if ae, ok := a.(*Error); ok {
+		be, ok := b.(*Error)
+		return ok && ae == be
+	}
+	return equalErrors(a, b)
+}
+
+// equalErrors compares a and b with ==, reporting false if it panics
+func equalErrors(a, b error) (equal bool) {
+	defer func() {
+		if recover() != nil {
+			equal = false
+		}
+	}()
+	return a == b
+}
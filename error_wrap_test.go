@@ -0,0 +1,176 @@
+package fmt
+
+import "testing"
+
+// testPathError is a custom error type used to exercise As
+type testPathError struct{ path string }
+
+func (e *testPathError) Error() string { return "path error: " + e.path }
+
+// testSliceError has an uncomparable dynamic type
+type testSliceError []string
+
+func (e testSliceError) Error() string { return "slice error" }
+
+// testCodeError matches any testCodeError with the same code through Is
+type testCodeError struct{ code int }
+
+func (e testCodeError) Error() string { return "code error" }
+func (e testCodeError) Is(target error) bool {
+	t, ok := target.(testCodeError)
+	return ok && t.code == e.code
+}
+
+// testHolderError is comparable by type but holds an interface that may not be
+type testHolderError struct{ inner error }
+
+func (e testHolderError) Error() string { return "holder: " + e.inner.Error() }
+
+// testValueError is a plain struct error without an Is method
+type testValueError struct{ code int }
+
+func (e testValueError) Error() string { return "value error" }
+
+func TestErrfWrap(t *testing.T) {
+	inner := Err(D.Files, D.Not, D.Found)
+	err := Errf("load: %w", inner)
+
+	if got := err.Error(); got != "load: Files Not Found" {
+		t.Errorf("unexpected message %q", got)
+	}
+	if Unwrap(err) != inner {
+		t.Error("expected Unwrap to return the wrapped error")
+	}
+	if !Is(err, inner) {
+		t.Error("expected Is to find the wrapped error")
+	}
+
+	t.Run("non error argument", func(t *testing.T) {
+		if e := Errf("bad: %w", "text"); e.Code() != "invalid_type_of_argument" {
+			t.Errorf("expected invalid argument error, got %q", e.Error())
+		}
+	})
+
+	t.Run("multiple %w", func(t *testing.T) {
+		a, b := Err("a"), Err("b")
+		e := Errf("%w and %w", a, b)
+		if !Is(e, a) || !Is(e, b) {
+			t.Error("expected both errors in the chain")
+		}
+		if Unwrap(e) != nil {
+			t.Error("expected Unwrap to return nil for several wrapped errors")
+		}
+	})
+
+	t.Run("%v does not wrap", func(t *testing.T) {
+		if e := Errf("x: %v", inner); Is(e, inner) {
+			t.Error("expected the v verb not to wrap")
+		}
+	})
+
+	t.Run("Fmt accepts %w", func(t *testing.T) {
+		if got := Fmt("x: %w", inner); got != "x: Files Not Found" {
+			t.Errorf("unexpected Fmt output %q", got)
+		}
+	})
+}
+
+func TestErrWrapsErrorArguments(t *testing.T) {
+	inner := &testPathError{path: "/etc"}
+	err := Err(D.Failed, inner)
+
+	if got := err.Error(); got != "Failed path error: /etc" {
+		t.Errorf("unexpected message %q", got)
+	}
+	if Unwrap(err) != inner {
+		t.Error("expected Err to wrap its error argument")
+	}
+
+	_, converted := Convert(inner).StringErr()
+	if Unwrap(converted) != inner {
+		t.Error("expected Convert(err) to wrap err")
+	}
+}
+
+func TestIs(t *testing.T) {
+	base := Err("base")
+	mid := Errf("mid: %w", base)
+	top := Errf("top: %w", mid)
+
+	if !Is(top, base) || !Is(top, mid) || !Is(top, top) {
+		t.Error("expected every chain member to match")
+	}
+	if Is(top, Err("base")) {
+		t.Error("expected a different error value not to match")
+	}
+	if !Is(nil, nil) || Is(nil, base) || Is(base, nil) {
+		t.Error("unexpected nil handling")
+	}
+	if !Is(Errf("w: %w", testCodeError{7}), testCodeError{7}) {
+		t.Error("expected Is method to be used")
+	}
+	if Is(testSliceError{"a"}, testSliceError{"a"}) {
+		t.Error("uncomparable errors must not match or panic")
+	}
+	holder := testHolderError{Err("inner")}
+	if !Is(holder, holder) || !Is(Errf("wrap: %w", holder), holder) {
+		t.Error("expected struct errors holding an error to match themselves, like errors.Is")
+	}
+	if Is(holder, testHolderError{Err("inner")}) {
+		t.Error("expected holders of different errors not to match")
+	}
+	sliceHolder := testHolderError{testSliceError{"a"}}
+	_ = Classify(sliceHolder) // Compares with io.EOF and ErrSyntax
+	if Is(sliceHolder, sliceHolder) {
+		t.Error("errors holding uncomparable values must not match or panic")
+	}
+	if !Is(Errf("w: %w", testValueError{3}), testValueError{3}) || Is(testValueError{3}, testValueError{4}) {
+		t.Error("expected plain struct errors to compare with ==")
+	}
+}
+
+func TestAs(t *testing.T) {
+	inner := &testPathError{path: "/tmp"}
+	err := Errf("open: %w", inner)
+
+	var pe *testPathError
+	if !As(err, &pe) || pe != inner {
+		t.Error("expected As to find *testPathError")
+	}
+
+	var ce *Error
+	if As(error(inner), &ce) {
+		t.Error("expected As not to match a different type")
+	}
+
+	var asInterface interface{ Error() string }
+	if !As(err, &asInterface) {
+		t.Error("expected As to match an interface target")
+	}
+
+	if As(nil, &pe) {
+		t.Error("expected false for nil error")
+	}
+}
+
+func TestJoinErrors(t *testing.T) {
+	a, b := Err("first"), Err("second")
+
+	if Join(nil, nil) != nil {
+		t.Error("expected nil when all errors are nil")
+	}
+
+	err := Join(a, nil, b)
+	if got := err.Error(); got != "first\nsecond" {
+		t.Errorf("unexpected message %q", got)
+	}
+	if !Is(err, a) || !Is(err, b) {
+		t.Error("expected Is to find joined errors")
+	}
+	if Unwrap(err) != nil {
+		t.Error("expected Unwrap to return nil for joined errors")
+	}
+	if single := Join(a); Unwrap(single) != a {
+		t.Error("expected Unwrap to return the only joined error")
+	}
+}
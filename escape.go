@@ -0,0 +1,453 @@
+package fmt
+
+import "unicode/utf8"
+
+// Context escapers for values placed in inline scripts, styles and URLs
+// (see EscapeHTML and EscapeAttr for HTML content and attributes).
+// Escapers return the escaped text and release the Conv; decoders return the
+// decoded text and an error that wraps ErrSyntax for malformed escapes.
+
+// EscapeJS returns a string safe to place inside a JavaScript string literal
+// delimited by ', " or ` (template literals included). Quotes, backslashes,
+// `$`, control characters, U+2028, U+2029 and the HTML characters <, > and &
+// are escaped, so the value cannot close an inline <script> element.
+//
+//	Html("<script>let q = '%s'</script>", Convert(`it's </script>`).EscapeJS())
+//	// let q = 'it\'s \u003c/script\u003e'
+func (c *Conv) EscapeJS() string {
+	return c.transformOut(c.wrJSEscaped).String()
+}
+
+// UnescapeJS decodes JavaScript string escapes: \n, \t..., \xHH, \uHHHH,
+// \u{H...}, surrogate pairs, line continuations and identity escapes (\').
+func (c *Conv) UnescapeJS() (string, error) {
+	return c.transformOut(c.wrJSUnescaped).StringErr()
+}
+
+// EscapeJSON returns s escaped for a JSON string, without the surrounding
+// quotes, like the strings written by JSON.
+//
+//	`{"name":"` + Convert(name).EscapeJSON() + `"}`
+func (c *Conv) EscapeJSON() string {
+	return c.transformOut(func(dest BuffDest, s string) bool {
+		c.wrJSONEscaped(dest, s)
+		return true
+	}).String()
+}
+
+// UnescapeJSON decodes the escapes of a JSON string given without quotes
+func (c *Conv) UnescapeJSON() (string, error) {
+	return c.transformOut(c.wrJSONUnescaped).StringErr()
+}
+
+// EscapeCSS returns a string safe to place inside a quoted CSS string or
+// url(...). Quotes, backslashes, parentheses, braces, ;, :, /, +, <, >, &
+// and control characters are written as hex escapes (`"` -> `\22`).
+//
+//	Html(`<div style="font-family:'%s'">`, Convert(font).EscapeCSS())
+func (c *Conv) EscapeCSS() string {
+	return c.transformOut(c.wrCSSEscaped).String()
+}
+
+// EscapeCSSIdent returns s as a CSS identifier (class name, id, custom
+// property) following the CSSOM "serialize an identifier" rules.
+//
+//	Convert("1st item").EscapeCSSIdent() // `\31 st\ item`
+func (c *Conv) EscapeCSSIdent() string {
+	return c.transformOut(c.wrCSSIdent).String()
+}
+
+// UnescapeCSS decodes CSS escapes: `\` followed by 1-6 hex digits and an
+// optional space, escaped line breaks and `\` followed by any character.
+func (c *Conv) UnescapeCSS() string {
+	return c.transformOut(c.wrCSSUnescaped).String()
+}
+
+// EscapeQuery escapes s for a URL query parameter name or value, like
+// url.QueryEscape: spaces become '+' and bytes other than letters, digits
+// and -_.~ become %XX.
+//
+//	"/search?q=" + Convert("tom & jerry").EscapeQuery() // q=tom+%26+jerry
+func (c *Conv) EscapeQuery() string {
+	return c.transformOut(func(dest BuffDest, s string) bool {
+		c.wrURLEscaped(dest, s, true)
+		return true
+	}).String()
+}
+
+// EscapePath escapes s for one URL path segment, like url.PathEscape:
+// '/' and spaces are escaped, $&+,:;=@ are kept.
+//
+//	"/users/" + Convert("ana/b").EscapePath() // /users/ana%2Fb
+func (c *Conv) EscapePath() string {
+	return c.transformOut(func(dest BuffDest, s string) bool {
+		c.wrURLEscaped(dest, s, false)
+		return true
+	}).String()
+}
+
+// UnescapeQuery decodes %XX sequences and '+' as space, like url.QueryUnescape
+func (c *Conv) UnescapeQuery() (string, error) {
+	return c.transformOut(func(dest BuffDest, s string) bool {
+		return c.wrURLUnescaped(dest, s, true)
+	}).StringErr()
+}
+
+// UnescapePath decodes %XX sequences and keeps '+', like url.PathUnescape
+func (c *Conv) UnescapePath() (string, error) {
+	return c.transformOut(func(dest BuffDest, s string) bool {
+		return c.wrURLUnescaped(dest, s, false)
+	}).StringErr()
+}
+
+// transformOut replaces the output with fn(output) written to BuffWork.
+// fn returns false after setting an error; the output is then left as is.
+func (c *Conv) transformOut(fn func(dest BuffDest, s string) bool) *Conv {
+	if c.hasContent(BuffErr) {
+		return c // Error chain interruption
+	}
+	c.ResetBuffer(BuffWork)
+	if fn(BuffWork, c.GetString(BuffOut)) {
+		c.swapBuff(BuffWork, BuffOut)
+	}
+	return c
+}
+
+// wrJSEscaped writes s escaped for a JavaScript string literal
+func (c *Conv) wrJSEscaped(dest BuffDest, s string) bool {
+	start := 0
+	for i := 0; i < len(s); {
+		b := s[i]
+		if b >= utf8.RuneSelf {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			if r == '\u2028' || r == '\u2029' {
+				c.WrString(dest, s[start:i])
+				c.wrEscape(dest, 'u', r, 4)
+				start = i + size
+			}
+			i += size
+			continue
+		}
+		var esc string
+		switch b {
+		case '\\':
+			esc = `\\`
+		case '\'':
+			esc = `\'`
+		case '"':
+			esc = `\"`
+		case '\n':
+			esc = `\n`
+		case '\r':
+			esc = `\r`
+		case '\t':
+			esc = `\t`
+		case '`', '$', '<', '>', '&':
+		default:
+			if b >= ' ' && b != 0x7F {
+				i++
+				continue
+			}
+		}
+		c.WrString(dest, s[start:i])
+		if esc != "" {
+			c.WrString(dest, esc)
+		} else {
+			c.wrEscape(dest, 'u', rune(b), 4)
+		}
+		i++
+		start = i
+	}
+	c.WrString(dest, s[start:])
+	return true
+}
+
+// wrJSUnescaped writes s with its JavaScript escapes decoded
+func (c *Conv) wrJSUnescaped(dest BuffDest, s string) bool {
+	for i := 0; i < len(s); {
+		if s[i] != '\\' {
+			c.wrByte(dest, s[i])
+			i++
+			continue
+		}
+		if i+1 >= len(s) {
+			c.wrErrKind(ErrSyntax, D.Character, D.Invalid, `\`)
+			return false
+		}
+		e := s[i+1]
+		size := 2
+		switch e {
+		case 'b':
+			c.wrByte(dest, '\b')
+		case 'f':
+			c.wrByte(dest, '\f')
+		case 'n':
+			c.wrByte(dest, '\n')
+		case 'r':
+			c.wrByte(dest, '\r')
+		case 't':
+			c.wrByte(dest, '\t')
+		case 'v':
+			c.wrByte(dest, '\v')
+		case '0':
+			c.wrByte(dest, 0)
+		case '\n': // Line continuation
+		case '\r':
+			if i+2 < len(s) && s[i+2] == '\n' {
+				size = 3
+			}
+		case 'x', 'u':
+			r, n, ok := parseJSHex(s[i:])
+			if !ok {
+				c.wrErrKind(ErrSyntax, D.Character, D.Invalid, s[i:min(i+n, len(s))])
+				return false
+			}
+			size = n
+			// Surrogate pair written as two \u escapes
+			if r >= 0xD800 && r < 0xDC00 && i+n+1 < len(s) && s[i+n] == '\\' && s[i+n+1] == 'u' {
+				if r2, n2, ok2 := parseJSHex(s[i+n:]); ok2 && r2 >= 0xDC00 && r2 < 0xE000 {
+					r = (r-0xD800)<<10 + (r2 - 0xDC00) + 0x10000
+					size += n2
+				}
+			}
+			if r >= 0xD800 && r < 0xE000 {
+				r = utf8.RuneError
+			}
+			var enc [utf8.UTFMax]byte
+			c.wrBytes(dest, enc[:utf8.EncodeRune(enc[:], r)])
+		default:
+			c.wrByte(dest, e) // Identity escape: \' \" \\ \/ ...
+		}
+		i += size
+	}
+	return true
+}
+
+// parseJSHex reads \xHH, \uHHHH or \u{H...} at the start of s and returns
+// the rune and the escape length
+func parseJSHex(s string) (r rune, size int, ok bool) {
+	digits, start := 2, 2
+	if s[1] == 'u' {
+		digits = 4
+		if len(s) > 2 && s[2] == '{' {
+			end := 3
+			for end < len(s) && s[end] != '}' {
+				end++
+			}
+			if end >= len(s) || end == 3 || end > 9 {
+				return 0, end + 1, false
+			}
+			digits, start = end-3, 3
+		}
+	}
+	if len(s) < start+digits {
+		return 0, len(s), false
+	}
+	for _, h := range []byte(s[start : start+digits]) {
+		v := hexValue(h)
+		if v < 0 {
+			return 0, start + digits, false
+		}
+		r = r<<4 | rune(v)
+	}
+	size = start + digits
+	if start == 3 {
+		size++ // Closing brace
+		if r > utf8.MaxRune {
+			return 0, size, false
+		}
+	}
+	return r, size, true
+}
+
+// hexValue returns the value of a hex digit, or -1
+func hexValue(h byte) int {
+	switch {
+	case h >= '0' && h <= '9':
+		return int(h - '0')
+	case h >= 'a' && h <= 'f':
+		return int(h-'a') + 10
+	case h >= 'A' && h <= 'F':
+		return int(h-'A') + 10
+	}
+	return -1
+}
+
+// wrJSONUnescaped writes the JSON string content s decoded, reusing the
+// tokenizer
+func (c *Conv) wrJSONUnescaped(dest BuffDest, s string) bool {
+	t := NewJSONTokenizer(`"` + s + `"`)
+	tok, err := t.Next()
+	if err == nil && t.pos != len(t.data) {
+		err = t.errChar() // Unescaped quote inside s
+	}
+	if err != nil {
+		c.wrErrKind(ErrSyntax, err)
+		return false
+	}
+	c.WrString(dest, tok.Value)
+	return true
+}
+
+// wrCSSEscaped writes s escaped for a quoted CSS string
+func (c *Conv) wrCSSEscaped(dest BuffDest, s string) bool {
+	start := 0
+	for i := 0; i < len(s); i++ {
+		b := s[i]
+		switch {
+		case b < ' ' || b == 0x7F:
+		case b == '"', b == '\'', b == '\\', b == '(', b == ')', b == '{', b == '}',
+			b == ';', b == ':', b == '/', b == '+', b == '<', b == '>', b == '&':
+		default:
+			continue
+		}
+		c.WrString(dest, s[start:i])
+		var next byte
+		if i+1 < len(s) {
+			next = s[i+1]
+		}
+		c.wrCSSHex(dest, rune(b), next)
+		start = i + 1
+	}
+	c.WrString(dest, s[start:])
+	return true
+}
+
+// wrCSSHex writes `\` and r in hex, followed by a space when next would be
+// read as part of the escape (hex digit or whitespace)
+func (c *Conv) wrCSSHex(dest BuffDest, r rune, next byte) {
+	c.wrByte(dest, '\\')
+	started := false
+	for shift := 20; shift >= 0; shift -= 4 {
+		d := (r >> uint(shift)) & 0xF
+		if d != 0 || started || shift == 0 {
+			c.wrByte(dest, hexDigits[d])
+			started = true
+		}
+	}
+	if next != 0 && (hexValue(next) >= 0 || next == ' ' || next == '\t' || next == '\n' || next == '\r' || next == '\f') {
+		c.wrByte(dest, ' ')
+	}
+}
+
+// wrCSSIdent writes s serialized as a CSS identifier (CSSOM)
+func (c *Conv) wrCSSIdent(dest BuffDest, s string) bool {
+	if s == "-" {
+		c.WrString(dest, `\-`)
+		return true
+	}
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == 0:
+			c.WrString(dest, "\ufffd")
+		case r < ' ' || r == 0x7F,
+			r >= '0' && r <= '9' && (i == 0 || (i == 1 && s[0] == '-')):
+			c.wrCSSHex(dest, r, 0)
+			c.wrByte(dest, ' ') // Always terminated in identifiers
+		case r >= utf8.RuneSelf, r == '-', r == '_',
+			r >= '0' && r <= '9', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+			c.WrString(dest, s[i:i+size])
+		default:
+			c.wrByte(dest, '\\')
+			c.wrByte(dest, byte(r))
+		}
+		i += size
+	}
+	return true
+}
+
+// wrCSSUnescaped writes s with its CSS escapes decoded
+func (c *Conv) wrCSSUnescaped(dest BuffDest, s string) bool {
+	for i := 0; i < len(s); {
+		if s[i] != '\\' {
+			c.wrByte(dest, s[i])
+			i++
+			continue
+		}
+		i++
+		if i >= len(s) {
+			break // Trailing backslash
+		}
+		if hexValue(s[i]) < 0 {
+			switch s[i] {
+			case '\n', '\f': // Escaped line break in a string
+			case '\r':
+				if i+1 < len(s) && s[i+1] == '\n' {
+					i++
+				}
+			default:
+				_, size := utf8.DecodeRuneInString(s[i:])
+				c.WrString(dest, s[i:i+size])
+				i += size
+				continue
+			}
+			i++
+			continue
+		}
+		var r rune
+		n := 0
+		for ; n < 6 && i < len(s) && hexValue(s[i]) >= 0; n++ {
+			r = r<<4 | rune(hexValue(s[i]))
+			i++
+		}
+		if i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\n' || s[i] == '\f') {
+			i++
+		} else if i < len(s) && s[i] == '\r' {
+			i++
+			if i < len(s) && s[i] == '\n' {
+				i++
+			}
+		}
+		if r == 0 || !utf8.ValidRune(r) {
+			r = utf8.RuneError
+		}
+		var enc [utf8.UTFMax]byte
+		c.wrBytes(dest, enc[:utf8.EncodeRune(enc[:], r)])
+	}
+	return true
+}
+
+// wrURLEscaped writes s percent-encoded for a query (spaces as '+') or a
+// path segment
+func (c *Conv) wrURLEscaped(dest BuffDest, s string, query bool) {
+	const upperHex = "0123456789ABCDEF"
+	for i := 0; i < len(s); i++ {
+		b := s[i]
+		switch {
+		case b >= 'a' && b <= 'z', b >= 'A' && b <= 'Z', b >= '0' && b <= '9',
+			b == '-', b == '_', b == '.', b == '~':
+			c.wrByte(dest, b)
+		case b == ' ' && query:
+			c.wrByte(dest, '+')
+		case !query && (b == '$' || b == '&' || b == '+' || b == ',' || b == ':' ||
+			b == ';' || b == '=' || b == '@'):
+			c.wrByte(dest, b)
+		default:
+			c.wrByte(dest, '%')
+			c.wrByte(dest, upperHex[b>>4])
+			c.wrByte(dest, upperHex[b&0xF])
+		}
+	}
+}
+
+// wrURLUnescaped writes s with %XX sequences decoded, and '+' as space for
+// queries
+func (c *Conv) wrURLUnescaped(dest BuffDest, s string, query bool) bool {
+	for i := 0; i < len(s); i++ {
+		switch b := s[i]; {
+		case b == '%':
+			if i+2 >= len(s) || hexValue(s[i+1]) < 0 || hexValue(s[i+2]) < 0 {
+				c.wrErrKind(ErrSyntax, D.Format, D.Invalid, s[i:min(i+3, len(s))])
+				return false
+			}
+			c.wrByte(dest, byte(hexValue(s[i+1])<<4|hexValue(s[i+2])))
+			i += 2
+		case b == '+' && query:
+			c.wrByte(dest, ' ')
+		default:
+			c.wrByte(dest, b)
+		}
+	}
+	return true
+}
@@ -0,0 +1,154 @@
+package fmt
+
+import "testing"
+
+func TestEscapeJS(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"plain text", "plain text"},
+		{`it's "x"`, `it\'s \"x\"`},
+		{`a\b`, `a\\b`},
+		{"</script>", `\u003c/script\u003e`},
+		{"`${x}` & y", `\u0060\u0024{x}\u0060 \u0026 y`},
+		{"l1\nl2\r\t\x00\x7f", `l1\nl2\r\t\u0000\u007f`},
+		{"a\u2028b\u2029", `a\u2028b\u2029`},
+		{"\u00f1and\u00fa \U0001F600", "\u00f1and\u00fa \U0001F600"},
+	}
+	for _, tt := range tests {
+		if got := Convert(tt.in).EscapeJS(); got != tt.want {
+			t.Errorf("EscapeJS(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+		if back, err := Convert(tt.want).UnescapeJS(); err != nil || back != tt.in {
+			t.Errorf("UnescapeJS(%s) = %q, %v, want %q", tt.want, back, err, tt.in)
+		}
+	}
+}
+
+func TestUnescapeJS(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`\x41\u00e9\u{1F600}`, "A\u00e9\U0001F600"},
+		{`\ud83d\ude00`, "\U0001F600"},
+		{`\ud83d`, "\ufffd"},
+		{`\/\q\0`, "/q\x00"},
+		{"line\\\ncontinued", "linecontinued"},
+		{`\b\f\v`, "\b\f\v"},
+	}
+	for _, tt := range tests {
+		if got, err := Convert(tt.in).UnescapeJS(); err != nil || got != tt.want {
+			t.Errorf("UnescapeJS(%s) = %q, %v, want %q", tt.in, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{`\x4`, `\xzz`, `\u12`, `\u{}`, `\u{110000}`, `\u{41`, `end\`} {
+		if _, err := Convert(bad).UnescapeJS(); !Is(err, ErrSyntax) {
+			t.Errorf("UnescapeJS(%s): expected ErrSyntax, got %v", bad, err)
+		}
+	}
+}
+
+func TestEscapeJSON(t *testing.T) {
+	in := "say \"hi\"\n<b>\x01"
+	want := `say \"hi\"\n\u003cb\u003e\u0001`
+	if got := Convert(in).EscapeJSON(); got != want {
+		t.Errorf("EscapeJSON = %s, want %s", got, want)
+	}
+	if got, err := Convert(want).UnescapeJSON(); err != nil || got != in {
+		t.Errorf("UnescapeJSON = %q, %v, want %q", got, err, in)
+	}
+	if got, err := Convert(`\u00e9\/\ud83d\ude00`).UnescapeJSON(); err != nil || got != "\u00e9/\U0001F600" {
+		t.Errorf("UnescapeJSON unicode = %q, %v", got, err)
+	}
+	for _, bad := range []string{`a"b`, `\q`, `\u12`, "tab\there", `end\`} {
+		if _, err := Convert(bad).UnescapeJSON(); !Is(err, ErrSyntax) {
+			t.Errorf("UnescapeJSON(%q): expected ErrSyntax, got %v", bad, err)
+		}
+	}
+}
+
+func TestEscapeCSS(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Open Sans", "Open Sans"},
+		{`a"b`, `a\22 b`},
+		{`x');}`, `x\27\29\3b\7d`},
+		{"</style>", `\3c\2fstyle\3e`},
+		{"a\nb", `a\a b`},
+		{"1;", `1\3b`},
+		{"é", "é"},
+	}
+	for _, tt := range tests {
+		if got := Convert(tt.in).EscapeCSS(); got != tt.want {
+			t.Errorf("EscapeCSS(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+		if back := Convert(tt.want).UnescapeCSS(); back != tt.in {
+			t.Errorf("UnescapeCSS(%s) = %q, want %q", tt.want, back, tt.in)
+		}
+	}
+}
+
+func TestEscapeCSSIdent(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"item", "item"},
+		{"1st item", `\31 st\ item`},
+		{"-2x", `-\32 x`},
+		{"-", `\-`},
+		{"--var_name", "--var_name"},
+		{"a.b#c", `a\.b\#c`},
+		{"a\x01b", `a\1 b`},
+		{"a\x00", "a\ufffd"},
+		{"\u00f1", "\u00f1"},
+	}
+	for _, tt := range tests {
+		if got := Convert(tt.in).EscapeCSSIdent(); got != tt.want {
+			t.Errorf("EscapeCSSIdent(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+	if got := Convert(`\1F600 x\0 \110000\"`).UnescapeCSS(); got != "\U0001F600x\ufffd\ufffd\"" {
+		t.Errorf("UnescapeCSS = %q", got)
+	}
+}
+
+func TestEscapeURL(t *testing.T) {
+	tests := []struct {
+		in    string
+		query string
+		path  string
+	}{
+		{"tom & jerry", "tom+%26+jerry", "tom%20&%20jerry"},
+		{"a/b?c=d", "a%2Fb%3Fc%3Dd", "a%2Fb%3Fc=d"},
+		{"ñ-_.~", "%C3%B1-_.~", "%C3%B1-_.~"},
+		{"1+1@x:$;,", "1%2B1%40x%3A%24%3B%2C", "1+1@x:$;,"},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		if got := Convert(tt.in).EscapeQuery(); got != tt.query {
+			t.Errorf("EscapeQuery(%q) = %s, want %s", tt.in, got, tt.query)
+		}
+		if got := Convert(tt.in).EscapePath(); got != tt.path {
+			t.Errorf("EscapePath(%q) = %s, want %s", tt.in, got, tt.path)
+		}
+		if got, err := Convert(tt.query).UnescapeQuery(); err != nil || got != tt.in {
+			t.Errorf("UnescapeQuery(%s) = %q, %v", tt.query, got, err)
+		}
+		if got, err := Convert(tt.path).UnescapePath(); err != nil || got != tt.in {
+			t.Errorf("UnescapePath(%s) = %q, %v", tt.path, got, err)
+		}
+	}
+	if got, _ := Convert("a+b%2b").UnescapePath(); got != "a+b+" {
+		t.Errorf("UnescapePath keeps '+': got %q", got)
+	}
+	for _, bad := range []string{"%", "%4", "%zz", "a%g1"} {
+		if _, err := Convert(bad).UnescapeQuery(); !Is(err, ErrSyntax) {
+			t.Errorf("UnescapeQuery(%q): expected ErrSyntax, got %v", bad, err)
+		}
+	}
+}
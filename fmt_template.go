@@ -26,7 +26,7 @@ func Fprintf(w io.Writer, format string, args ...any) (n int, err error) {
 
 	// Check for formatting errors
 	if c.hasContent(BuffErr) {
-		return 0, c
+		return 0, c.newError()
 	}
 
 	// Write to io.Writer
@@ -47,19 +47,23 @@ func Sscanf(src string, format string, args ...any) (n int, err error) {
 
 	// Check for parsing errors
 	if c.hasContent(BuffErr) {
-		return n, c
+		return n, c.newError()
 	}
 
 	return n, nil
 }
 
 // applyWidthAndAlignment applies width formatting and alignment to a string
+// Width is measured in characters (runes), not bytes, so non-ASCII text aligns.
+// ANSI escape sequences (see Color) take no width, so coloured text aligns too.
+// Padding is written in logical order in every language; placing it visually
+// in right-to-left text is left to the bidi renderer.
 func (c *Conv) applyWidthAndAlignment(str string, width int, leftAlign bool, zeroPad bool) string {
 	if width <= 0 {
 		return str
 	}
 
-	strLen := len(str)
+	strLen := visibleLen(str, true)
 	pad := width - strLen
 
 	if leftAlign {
@@ -76,7 +80,7 @@ func (c *Conv) applyWidthAndAlignment(str string, width int, leftAlign bool, zer
 		}
 	} else if strLen > width {
 		// Truncar si el string es más largo que el ancho
-		return str[:width]
+		return visiblePrefix(str, width, true)
 	}
 	return str
 }
@@ -154,8 +158,12 @@ func (c *Conv) wrFormat(dest BuffDest, currentLang lang, format string, args ...
 // Returns formatChar, param, formatSpec, width, leftAlign, zeroPad, and new index position
 func (c *Conv) parseFormatSpecifier(format string, i int) (formatChar rune, param int, formatSpec string, width int, leftAlign bool, zeroPad bool, newI int) {
 	// Parse flags
+	plus := false
 	for i < len(format) {
-		if format[i] == '-' {
+		if format[i] == '+' && i+1 < len(format) && format[i+1] == 'v' {
+			plus = true // %+v: errors include their creation stack (see caller.debug.go)
+			i++
+		} else if format[i] == '-' {
 			leftAlign = true
 			i++
 		} else if format[i] == '0' {
@@ -227,6 +235,9 @@ func (c *Conv) parseFormatSpecifier(format string, i int) (formatChar rune, para
 		formatChar, param, formatSpec = 't', 0, "%t"
 	case 'v':
 		formatChar, param, formatSpec = 'v', 0, "%v"
+		if plus {
+			formatSpec = "%+v"
+		}
 	case 'q':
 		formatChar, param, formatSpec = 'q', 0, "%q"
 	case 's':
@@ -235,6 +246,8 @@ func (c *Conv) parseFormatSpecifier(format string, i int) (formatChar rune, para
 		formatChar, param, formatSpec = '%', 0, "%%"
 	case 'L':
 		formatChar, param, formatSpec = 'L', 0, "%L"
+	case 'w':
+		formatChar, param, formatSpec = 'w', 0, "%w"
 	default:
 		formatChar, param, formatSpec = rune(format[i]), 0, ""
 	}
@@ -253,8 +266,9 @@ func (c *Conv) isValidFormatChar(ch rune) bool {
 }
 
 // isValidWriteFormatChar validates format characters for write operations (reuses isValidFormatChar)
+// %w (wrapped error) is write-only.
 func (c *Conv) isValidWriteFormatChar(ch rune) bool {
-	return c.isValidFormatChar(ch)
+	return ch == 'w' || c.isValidFormatChar(ch)
 }
 
 // spaces returns a string with n spaces
@@ -372,11 +386,11 @@ func (c *Conv) formatValue(arg any, formatChar rune, param int, formatSpec strin
 		// Quoted string or rune
 		switch v := arg.(type) {
 		case string:
-			return "\"" + v + "\""
+			return Convert(v).Quote().String()
 		case rune:
-			return "'" + string(v) + "'"
+			return QuoteRune(v)
 		case byte:
-			return "'" + string(rune(v)) + "'"
+			return QuoteRune(rune(v))
 		}
 		c.wrInvalidTypeErr(formatSpec)
 		return ""
@@ -447,6 +461,9 @@ func (c *Conv) formatValue(arg any, formatChar rune, param int, formatSpec strin
 		c.ResetBuffer(BuffWork)
 		if errVal, ok := arg.(error); ok {
 			c.WrString(BuffWork, errVal.Error())
+			if e, ok := errVal.(*Error); ok && formatSpec == "%+v" {
+				c.WrString(BuffWork, e.caller.String())
+			}
 			return c.GetString(BuffWork)
 		} else {
 			c.AnyToBuff(BuffWork, arg)
@@ -455,6 +472,14 @@ func (c *Conv) formatValue(arg any, formatChar rune, param int, formatSpec strin
 			}
 			return c.GetString(BuffWork)
 		}
+	case 'w':
+		// Wrapped error: formatted like %v, kept for Errf (see Unwrap)
+		if errVal, ok := arg.(error); ok && errVal != nil {
+			c.wrapped = append(c.wrapped, errVal)
+			return errVal.Error()
+		}
+		c.wrInvalidTypeErr(formatSpec)
+		return ""
 	case 'L':
 		// Localized string formatting
 		var loc LocStr
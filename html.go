@@ -15,18 +15,14 @@ package fmt
 //	s := Convert(`Tom & Jerry's "House" <tag>`).EscapeAttr()
 //	// s == `Tom &amp; Jerry&#39;s &quot;House&quot; &lt;tag&gt;`
 //
-// Note: this method performs plain string replacements and does not detect
-// existing HTML entities. Calling EscapeAttr on a string that already
-// contains entities (for example `&amp;`) will produce double-escaped
-// output (`&amp;amp;`). This behavior is intentional and matches a simple
-// escape-for-attribute semantics.
-func (c *Conv) EscapeAttr() string {
-	return c.Replace("&", "&amp;").
-		Replace("\"", "&quot;").
-		Replace("'", "&#39;").
-		Replace("<", "&lt;").
-		Replace(">", "&gt;").
-		String()
+// By default existing entities are escaped again (`&amp;` -> `&amp;amp;`).
+// Pass true to leave valid entity references such as `&amp;`, `&eacute;`
+// or `&#39;` intact, so already escaped text is not escaped twice:
+//
+//	Convert(`Tom &amp; Jerry & <Co>`).EscapeAttr(true)
+//	// `Tom &amp; Jerry &amp; &lt;Co&gt;`
+func (c *Conv) EscapeAttr(keepEntities ...bool) string {
+	return c.EscapeHTML(keepEntities...)
 }
 
 // EscapeHTML returns a string safe for inclusion into HTML content.
@@ -44,15 +40,47 @@ func (c *Conv) EscapeAttr() string {
 //	s := Convert(`<div class="x">Tom & Jerry's</div>`).EscapeHTML()
 //	// s == `&lt;div class=&quot;x&quot;&gt;Tom &amp; Jerry&#39;s&lt;/div&gt;`
 //
-// Like EscapeAttr, this method uses simple replacements and will double-escape
-// existing entities.
-func (c *Conv) EscapeHTML() string {
-	return c.Replace("&", "&amp;").
-		Replace("<", "&lt;").
-		Replace(">", "&gt;").
-		Replace("\"", "&quot;").
-		Replace("'", "&#39;").
-		String()
+// Like EscapeAttr, existing entities are escaped again unless true is passed
+// to keep valid entity references. See UnescapeHTML for the reverse.
+func (c *Conv) EscapeHTML(keepEntities ...bool) string {
+	keep := len(keepEntities) > 0 && keepEntities[0]
+	return c.transformOut(func(dest BuffDest, s string) bool {
+		c.wrHTMLEscaped(dest, s, keep)
+		return true
+	}).String()
+}
+
+// wrHTMLEscaped writes s with &, <, >, " and ' replaced by entities; with
+// keep, complete entity references (ending in ';') are copied unchanged
+func (c *Conv) wrHTMLEscaped(dest BuffDest, s string, keep bool) {
+	start := 0
+	for i := 0; i < len(s); i++ {
+		var esc string
+		switch s[i] {
+		case '&':
+			if keep {
+				if _, size := htmlEntityAt(s[i:], true); size > 0 {
+					i += size - 1
+					continue
+				}
+			}
+			esc = "&amp;"
+		case '<':
+			esc = "&lt;"
+		case '>':
+			esc = "&gt;"
+		case '"':
+			esc = "&quot;"
+		case '\'':
+			esc = "&#39;"
+		default:
+			continue
+		}
+		c.WrString(dest, s[start:i])
+		c.WrString(dest, esc)
+		start = i + 1
+	}
+	c.WrString(dest, s[start:])
 }
 
 // Html creates a string for HTML content, similar to Translate but without automatic spacing.
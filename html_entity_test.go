@@ -0,0 +1,82 @@
+package fmt
+
+import "testing"
+
+func TestUnescapeHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"empty", "", ""},
+		{"no entities", "plain text", "plain text"},
+		{"markup", "&lt;b&gt;Tom &amp; Jerry&#39;s &quot;x&quot; &apos;y&apos;&lt;/b&gt;", `<b>Tom & Jerry's "x" 'y'</b>`},
+		{"named latin", "caf&eacute; &Ntilde;and&uacute; &copy;&nbsp;2024", "café Ñandú © 2024"},
+		{"named symbols", "&euro;5 &ndash; &hellip; &rarr; &alpha;&Omega; &thetasym;", "€5 – … → αΩ ϑ"},
+		{"decimal", "&#65;&#233;&#128512;", "Aé\U0001F600"},
+		{"hex", "&#x41;&#X1F600;&#xe9;", "A\U0001F600é"},
+		{"numeric without semicolon", "&#65B &#x41z", "AB Az"},
+		{"windows-1252", "&#128;&#150;&#153;", "€–™"},
+		{"invalid code points", "&#0;&#xD800;&#x110000;&#99999999999;", "\uFFFD\uFFFD\uFFFD\uFFFD"},
+		{"unknown named", "&foo; &ampx &amp", "&foo; &ampx &amp"},
+		{"malformed", "& &# &#x &#; &#xg; a&b", "& &# &#x &#; &#xg; a&b"},
+		{"double escaped once", "&amp;amp;", "&amp;"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Convert(tc.in).UnescapeHTML(); got != tc.want {
+				t.Fatalf("got=%q want=%q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUnescapeHTMLRoundTrip(t *testing.T) {
+	inputs := []string{
+		`<a href="x?a=1&b=2">Tom & Jerry's</a>`,
+		"café &amp; \U0001F600",
+		"",
+	}
+	for _, in := range inputs {
+		if got := Convert(Convert(in).EscapeHTML()).UnescapeHTML(); got != in {
+			t.Errorf("round trip %q: got %q", in, got)
+		}
+	}
+}
+
+func TestEscapeHTMLKeepEntities(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"empty", "", ""},
+		{"keeps named", "Tom &amp; Jerry & <Co>", "Tom &amp; Jerry &amp; &lt;Co&gt;"},
+		{"keeps numeric", "&#39;&#x1F600; &#65", "&#39;&#x1F600; &amp;#65"},
+		{"keeps latin", "caf&eacute; & \"x\"", "caf&eacute; &amp; &quot;x&quot;"},
+		{"unknown named", "&foo; &amp", "&amp;foo; &amp;amp"},
+		{"idempotent", "&lt;b&gt; &amp;", "&lt;b&gt; &amp;"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Convert(tc.in).EscapeHTML(true); got != tc.want {
+				t.Fatalf("EscapeHTML: got=%q want=%q", got, tc.want)
+			}
+			if got := Convert(tc.in).EscapeAttr(true); got != tc.want {
+				t.Fatalf("EscapeAttr: got=%q want=%q", got, tc.want)
+			}
+		})
+	}
+	// false keeps the default double escaping
+	if got := Convert("&amp;").EscapeAttr(false); got != "&amp;amp;" {
+		t.Fatalf("EscapeAttr(false): got=%q", got)
+	}
+}
+
+func BenchmarkUnescapeHTML(b *testing.B) {
+	s := "Tom &amp; Jerry&#39;s caf&eacute; &lt;b&gt; &#x1F600;"
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Convert(s).UnescapeHTML()
+	}
+}
@@ -0,0 +1,596 @@
+package fmt
+
+import (
+	"reflect"
+	"sync"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// JSON encodes v as JSON without encoding/json, keeping TinyGo binaries small.
+// Supported values: nil, bool, integers, floats, strings, []byte (base64),
+// slices, arrays, maps with string or integer keys (sorted), pointers,
+// interfaces, structs and values implementing MarshalJSON. LocStr values are
+// written as text in the current language.
+//
+// Struct fields follow the json tag (read with TagValue): a name, "-" to skip
+// the field, ",omitempty" and ",string". Unexported fields are skipped and
+// embedded structs without a name are inlined; an outer field hides embedded
+// ones with the same name, like encoding/json.
+//
+//	type User struct {
+//		Name  string `json:"name"`
+//		Email string `json:"email,omitempty"`
+//		Age   int    `json:"age"`
+//	}
+//	JSON(User{Name: "Ana", Age: 30}).String() // {"name":"Ana","age":30}
+//	out, err := JSON(ch).StringErr()          // err wraps ErrUnsupportedType
+//
+// Strings are escaped like encoding/json: quotes and backslashes, \b \f \n
+// \r \t, other control characters, <, > and & (safe inside HTML) as \u00XX,
+// U+2028 and U+2029; invalid UTF-8 bytes become \ufffd.
+// Floats are written in the shortest form that decodes to the same value, in
+// exponent form below 1e-6 and from 1e21 on; NaN, infinities and values that
+// contain themselves are errors.
+func JSON(v any) *Conv {
+	c := GetConv()
+	c.jsonRefs = c.jsonRefs[:0]
+	c.wrJSON(BuffOut, v)
+	return c
+}
+
+// wrJSON writes v as JSON to dest; on failure BuffErr holds the error
+func (c *Conv) wrJSON(dest BuffDest, v any) {
+	switch val := v.(type) {
+	case nil:
+		c.WrString(dest, "null")
+	case string:
+		c.wrJSONString(dest, val)
+	case bool:
+		c.wrBool(dest, val)
+	case int:
+		c.wrIntBase(dest, int64(val), 10, true)
+	case int64:
+		c.wrIntBase(dest, val, 10, true)
+	case int32:
+		c.wrIntBase(dest, int64(val), 10, true)
+	case uint:
+		c.wrIntBase(dest, int64(val), 10, false)
+	case uint64:
+		c.wrIntBase(dest, int64(val), 10, false)
+	case float64:
+		c.wrJSONFloat(dest, val, false)
+	case float32:
+		c.wrJSONFloat(dest, float64(val), true)
+	case LocStr:
+		c.wrJSONString(dest, Translate(val).String())
+	case []byte:
+		c.wrJSONBytes(dest, val)
+	case []string:
+		c.wrByte(dest, '[')
+		for i, s := range val {
+			if i > 0 {
+				c.wrByte(dest, ',')
+			}
+			c.wrJSONString(dest, s)
+		}
+		c.wrByte(dest, ']')
+	case []any:
+		if len(val) > 0 && !c.jsonEnter(uintptr(unsafe.Pointer(&val[0])), len(val), reflect.TypeOf(val)) {
+			return
+		}
+		c.wrByte(dest, '[')
+		for i := 0; i < len(val) && !c.hasContent(BuffErr); i++ {
+			if i > 0 {
+				c.wrByte(dest, ',')
+			}
+			c.wrJSON(dest, val[i])
+		}
+		c.wrByte(dest, ']')
+		if len(val) > 0 {
+			c.jsonLeave()
+		}
+	case interface{ MarshalJSON() ([]byte, error) }:
+		c.wrJSONMarshaler(dest, val)
+	default:
+		c.wrJSONValue(dest, reflect.ValueOf(v))
+	}
+}
+
+// wrJSONValue writes values without a fast path using reflection
+func (c *Conv) wrJSONValue(dest BuffDest, rv reflect.Value) {
+	// Named types with methods may implement MarshalJSON (e.g. time.Time)
+	if rv.IsValid() && rv.Type().NumMethod() > 0 && rv.CanInterface() {
+		if m, ok := rv.Interface().(interface{ MarshalJSON() ([]byte, error) }); ok {
+			c.wrJSONMarshaler(dest, m)
+			return
+		}
+	}
+	switch rv.Kind() {
+	case reflect.Invalid:
+		c.WrString(dest, "null")
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			c.WrString(dest, "null")
+			return
+		}
+		ptr := rv.Kind() == reflect.Ptr
+		if ptr && !c.jsonEnter(rv.Pointer(), 0, rv.Type()) {
+			return
+		}
+		if rv.Elem().CanInterface() {
+			c.wrJSON(dest, rv.Elem().Interface())
+		} else {
+			c.wrJSONValue(dest, rv.Elem()) // Reached through an unexported embedded struct
+		}
+		if ptr {
+			c.jsonLeave()
+		}
+	case reflect.Bool:
+		c.wrBool(dest, rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		c.wrIntBase(dest, rv.Int(), 10, true)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		c.wrIntBase(dest, int64(rv.Uint()), 10, false)
+	case reflect.Float32:
+		c.wrJSONFloat(dest, rv.Float(), true)
+	case reflect.Float64:
+		c.wrJSONFloat(dest, rv.Float(), false)
+	case reflect.String:
+		c.wrJSONString(dest, rv.String())
+	case reflect.Slice:
+		if rv.IsNil() {
+			c.WrString(dest, "null")
+			return
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			c.wrJSONBytes(dest, rv.Bytes())
+			return
+		}
+		if rv.Len() == 0 {
+			c.WrString(dest, "[]")
+			return
+		}
+		if !c.jsonEnter(rv.Pointer(), rv.Len(), rv.Type()) {
+			return
+		}
+		c.wrJSONArray(dest, rv)
+		c.jsonLeave()
+	case reflect.Array:
+		c.wrJSONArray(dest, rv)
+	case reflect.Map:
+		if rv.IsNil() {
+			c.WrString(dest, "null")
+			return
+		}
+		if !c.jsonEnter(rv.Pointer(), 0, rv.Type()) {
+			return
+		}
+		c.wrJSONMap(dest, rv)
+		c.jsonLeave()
+	case reflect.Struct:
+		c.wrByte(dest, '{')
+		c.wrJSONFields(dest, rv)
+		c.wrByte(dest, '}')
+	default:
+		c.wrErrKind(ErrUnsupportedType, D.Type, D.Not, D.Supported, rv.Type().String())
+	}
+}
+
+// jsonRef identifies a pointer, map or slice being written
+type jsonRef struct {
+	ptr uintptr
+	len int          // slices sharing an array differ by length
+	typ reflect.Type // a struct and its first field share an address
+}
+
+// jsonEnter records a reference before writing what it points to. It returns
+// false, with an error, when the reference is already being written: the
+// value contains itself and has no JSON form.
+func (c *Conv) jsonEnter(ptr uintptr, n int, typ reflect.Type) bool {
+	ref := jsonRef{ptr, n, typ}
+	for _, r := range c.jsonRefs {
+		if r == ref {
+			c.wrErrKind(ErrUnsupportedType, D.Value, D.Not, D.Supported, "cycle", typ.String())
+			return false
+		}
+	}
+	c.jsonRefs = append(c.jsonRefs, ref)
+	return true
+}
+
+// jsonLeave drops the reference recorded last by jsonEnter
+func (c *Conv) jsonLeave() {
+	c.jsonRefs = c.jsonRefs[:len(c.jsonRefs)-1]
+}
+
+// wrJSONArray writes the elements of a slice or array
+func (c *Conv) wrJSONArray(dest BuffDest, rv reflect.Value) {
+	c.wrByte(dest, '[')
+	for i := 0; i < rv.Len(); i++ {
+		if i > 0 {
+			c.wrByte(dest, ',')
+		}
+		if c.wrJSONValue(dest, rv.Index(i)); c.hasContent(BuffErr) {
+			return
+		}
+	}
+	c.wrByte(dest, ']')
+}
+
+// wrJSONMap writes a map with string or integer keys, sorted like encoding/json
+func (c *Conv) wrJSONMap(dest BuffDest, rv reflect.Value) {
+	keys := rv.MapKeys()
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		switch k.Kind() {
+		case reflect.String:
+			names[i] = k.String()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			names[i] = Convert(k.Int()).String()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			names[i] = Convert(k.Uint()).String()
+		default:
+			c.wrErrKind(ErrUnsupportedType, D.Type, D.Not, D.Supported, k.Type().String())
+			return
+		}
+	}
+	// Insertion sort: maps in JSON payloads are small
+	for i := 1; i < len(names); i++ {
+		for j := i; j > 0 && names[j] < names[j-1]; j-- {
+			names[j], names[j-1] = names[j-1], names[j]
+			keys[j], keys[j-1] = keys[j-1], keys[j]
+		}
+	}
+	c.wrByte(dest, '{')
+	for i, k := range keys {
+		if i > 0 {
+			c.wrByte(dest, ',')
+		}
+		c.wrJSONString(dest, names[i])
+		c.wrByte(dest, ':')
+		if c.wrJSONValue(dest, rv.MapIndex(k)); c.hasContent(BuffErr) {
+			return
+		}
+	}
+	c.wrByte(dest, '}')
+}
+
+// wrJSONFields writes the fields listed by jsonFields, without braces
+func (c *Conv) wrJSONFields(dest BuffDest, rv reflect.Value) {
+	first := true
+	for _, f := range jsonFields(rv.Type()) {
+		fv, ok := jsonFieldGet(rv, f.index)
+		if !ok || (f.omitEmpty && isEmptyJSON(fv)) {
+			continue
+		}
+		if !first {
+			c.wrByte(dest, ',')
+		}
+		first = false
+		c.wrJSONString(dest, f.name)
+		c.wrByte(dest, ':')
+		if f.asString && isScalarJSON(fv) {
+			c.wrJSONQuoted(dest, fv)
+		} else {
+			c.wrJSONValue(dest, fv)
+		}
+		if c.hasContent(BuffErr) {
+			return
+		}
+	}
+}
+
+// jsonField is a struct field encoded and decoded by JSON name
+type jsonField struct {
+	name      string
+	index     []int // path through embedded structs
+	tagged    bool  // name comes from the json tag
+	omitEmpty bool
+	asString  bool
+}
+
+var (
+	jsonFieldsMu    sync.RWMutex
+	jsonFieldsCache = map[reflect.Type][]jsonField{}
+)
+
+// jsonFields lists the fields of a struct type in declaration order,
+// inlining embedded structs. When several fields share a JSON name the
+// shallowest wins, then the tagged one; remaining ties are dropped, like
+// encoding/json. The result is cached per type.
+func jsonFields(rt reflect.Type) []jsonField {
+	jsonFieldsMu.RLock()
+	fields, ok := jsonFieldsCache[rt]
+	jsonFieldsMu.RUnlock()
+	if ok {
+		return fields
+	}
+
+	all := appendJSONFields(nil, rt, nil, []reflect.Type{rt})
+	for i, f := range all {
+		dominant := true
+		for j, g := range all {
+			if i != j && g.name == f.name && (len(g.index) < len(f.index) ||
+				(len(g.index) == len(f.index) && (g.tagged || !f.tagged))) {
+				dominant = false
+				break
+			}
+		}
+		if dominant {
+			fields = append(fields, f)
+		}
+	}
+
+	jsonFieldsMu.Lock()
+	jsonFieldsCache[rt] = fields
+	jsonFieldsMu.Unlock()
+	return fields
+}
+
+// appendJSONFields appends the fields of rt, reached through index, to
+// fields. parents holds the embedded types on the path, so a struct embedding
+// itself through a pointer is not expanded forever.
+func appendJSONFields(fields []jsonField, rt reflect.Type, index []int, parents []reflect.Type) []jsonField {
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name, omitEmpty, asString, ok := jsonFieldTag(field)
+		if !ok {
+			continue
+		}
+		path := append(index[:len(index):len(index)], i)
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				seen := false
+				for _, p := range parents {
+					seen = seen || p == ft
+				}
+				if !seen {
+					fields = appendJSONFields(fields, ft, path, append(parents[:len(parents):len(parents)], ft))
+				}
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue // Unexported
+		}
+		f := jsonField{name: name, index: path, tagged: name != "", omitEmpty: omitEmpty, asString: asString}
+		if name == "" {
+			f.name = field.Name
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// jsonFieldGet returns the field at index; ok is false when an embedded
+// pointer on the way is nil
+func jsonFieldGet(rv reflect.Value, index []int) (fv reflect.Value, ok bool) {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				return rv, false
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv, true
+}
+
+// jsonFieldTag reads the json tag of a field with TagValue.
+// ok is false when the field is skipped with "-".
+func jsonFieldTag(field reflect.StructField) (name string, omitEmpty, asString, ok bool) {
+	tc := Convert(string(field.Tag))
+	tag, found := tc.TagValue("json")
+	tc.putConv()
+	if !found {
+		return "", false, false, true
+	}
+	if tag == "-" {
+		return "", false, false, false
+	}
+	name = tag
+	for i := 0; i < len(tag); i++ {
+		if tag[i] == ',' {
+			name = tag[:i]
+			opts := tag[i:] + ","
+			omitEmpty = Contains(opts, ",omitempty,")
+			asString = Contains(opts, ",string,")
+			break
+		}
+	}
+	return name, omitEmpty, asString, true
+}
+
+// isEmptyJSON reports whether a value is omitted by ",omitempty"
+func isEmptyJSON(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Bool:
+		return !rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return rv.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// isScalarJSON reports whether ",string" applies to the value
+func isScalarJSON(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// wrJSONQuoted writes a scalar as a JSON string (",string" tag option)
+func (c *Conv) wrJSONQuoted(dest BuffDest, rv reflect.Value) {
+	tmp := GetConv()
+	tmp.wrJSONValue(BuffOut, rv)
+	if tmp.hasContent(BuffErr) {
+		c.wrErr(tmp.GetString(BuffErr))
+	} else {
+		c.wrJSONString(dest, tmp.GetString(BuffOut))
+	}
+	tmp.putConv()
+}
+
+// wrJSONMarshaler writes the output of a MarshalJSON method
+func (c *Conv) wrJSONMarshaler(dest BuffDest, m interface{ MarshalJSON() ([]byte, error) }) {
+	if rv := reflect.ValueOf(m); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		c.WrString(dest, "null")
+		return
+	}
+	out, err := m.MarshalJSON()
+	if err != nil {
+		c.wrErr(err)
+		return
+	}
+	c.wrBytes(dest, out)
+}
+
+// wrJSONFloat writes a JSON number: the shortest decimal that decodes back
+// to val at its bit size, in exponent form below 1e-6 and from 1e21 like
+// encoding/json. NaN and infinities have no JSON form.
+func (c *Conv) wrJSONFloat(dest BuffDest, val float64, is32 bool) {
+	if val != val || val > 1.7976931348623157e+308 || val < -1.7976931348623157e+308 {
+		c.wrErrKind(ErrUnsupportedType, D.Value, D.Not, D.Supported, Convert(val).String())
+		return
+	}
+	bits := 64
+	abs := val
+	if is32 {
+		bits = 32
+		abs = float64(float32(val))
+	}
+	if abs < 0 || (abs == 0 && 1/abs < 0) { // -0 keeps its sign
+		c.wrByte(dest, '-')
+		abs = -abs
+	}
+	exponent := abs != 0 && (abs < 1e-6 || abs >= 1e21)
+	if f32 := float32(abs); is32 {
+		exponent = abs != 0 && (f32 < 1e-6 || f32 >= 1e21) // float32 bounds
+	}
+	var d decimal
+	d.setShortest(abs, bits)
+	c.wrDecimal(dest, &d, exponent)
+}
+
+// hexDigits holds the lowercase digits of \u and \x escapes
+const hexDigits = "0123456789abcdef"
+
+// wrJSONString writes s as a quoted JSON string
+func (c *Conv) wrJSONString(dest BuffDest, s string) {
+	c.wrByte(dest, '"')
+	c.wrJSONEscaped(dest, s)
+	c.wrByte(dest, '"')
+}
+
+// wrJSONEscaped writes s escaped for a JSON string, without quotes
+func (c *Conv) wrJSONEscaped(dest BuffDest, s string) {
+	start := 0
+	for i := 0; i < len(s); {
+		b := s[i]
+		if b < utf8.RuneSelf {
+			if b >= 0x20 && b != '"' && b != '\\' && b != '<' && b != '>' && b != '&' {
+				i++
+				continue
+			}
+			c.WrString(dest, s[start:i])
+			switch b {
+			case '"', '\\':
+				c.wrByte(dest, '\\')
+				c.wrByte(dest, b)
+			case '\n':
+				c.WrString(dest, `\n`)
+			case '\r':
+				c.WrString(dest, `\r`)
+			case '\t':
+				c.WrString(dest, `\t`)
+			case '\b':
+				c.WrString(dest, `\b`)
+			case '\f':
+				c.WrString(dest, `\f`)
+			default:
+				// Other control characters and <, >, & (HTML safe)
+				c.WrString(dest, `\u00`)
+				c.wrByte(dest, hexDigits[b>>4])
+				c.wrByte(dest, hexDigits[b&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			c.WrString(dest, s[start:i])
+			c.WrString(dest, `\ufffd`)
+			i += size
+			start = i
+			continue
+		}
+		// U+2028 and U+2029 end lines in JavaScript
+		if r == '\u2028' || r == '\u2029' {
+			c.WrString(dest, s[start:i])
+			c.WrString(dest, `\u202`)
+			c.wrByte(dest, hexDigits[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	c.WrString(dest, s[start:])
+}
+
+// base64Std is the standard base64 alphabet used by encoding/json for []byte
+const base64Std = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+// wrJSONBytes writes b as a quoted standard base64 string
+func (c *Conv) wrJSONBytes(dest BuffDest, b []byte) {
+	if b == nil {
+		c.WrString(dest, "null")
+		return
+	}
+	c.wrByte(dest, '"')
+	for i := 0; i < len(b); i += 3 {
+		var n uint32
+		rem := len(b) - i
+		switch {
+		case rem >= 3:
+			n = uint32(b[i])<<16 | uint32(b[i+1])<<8 | uint32(b[i+2])
+		case rem == 2:
+			n = uint32(b[i])<<16 | uint32(b[i+1])<<8
+		default:
+			n = uint32(b[i]) << 16
+		}
+		c.wrByte(dest, base64Std[n>>18&0x3F])
+		c.wrByte(dest, base64Std[n>>12&0x3F])
+		if rem >= 2 {
+			c.wrByte(dest, base64Std[n>>6&0x3F])
+		} else {
+			c.wrByte(dest, '=')
+		}
+		if rem >= 3 {
+			c.wrByte(dest, base64Std[n&0x3F])
+		} else {
+			c.wrByte(dest, '=')
+		}
+	}
+	c.wrByte(dest, '"')
+}
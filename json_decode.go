@@ -0,0 +1,509 @@
+package fmt
+
+import (
+	"io"
+	"reflect"
+)
+
+// DecodeJSON decodes the JSON text held by the Conv into v, which must be a
+// non-nil pointer, and releases the Conv. It is the inverse of JSON: struct
+// fields are matched by json tag (read with TagValue) or field name, ignoring
+// case, and unknown keys are skipped. Into an `any` value, objects become
+// map[string]any, arrays []any and numbers float64.
+//
+//	var u User
+//	err := Convert(body).DecodeJSON(&u)
+//
+//	var m map[string]any
+//	err = Convert(`{"a":[1,true,null]}`).DecodeJSON(&m)
+//
+// Errors have type Msg.Parse and carry the byte offset as the "offset" field:
+// syntax errors wrap ErrSyntax and numbers too large for the target wrap
+// ErrRange.
+//
+//	err := Convert(`{"age":}`).DecodeJSON(&u)
+//	err.Error()                      // "Character Invalid '}' offset=7"
+//	err.(*Error).Field("offset")     // 7
+//	err.(*Error).Type() == Msg.Parse // true
+func (c *Conv) DecodeJSON(v any) error {
+	if c.hasContent(BuffErr) {
+		return c.releaseErr()
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		c.putConv()
+		return GetConv().wrErrKind(ErrUnsupportedType, D.Value, D.Must, D.Be, D.Pointer).releaseErr()
+	}
+
+	t := NewJSONTokenizer(c.GetString(BuffOut)) // Copy: strings in v outlive the Conv
+	err := c.decodeJSONNext(t, rv.Elem())
+	if err == nil {
+		// Only whitespace may follow the value
+		if tok, end := t.Next(); end == nil {
+			t.pos = tok.Offset
+			err = t.errChar()
+		} else if end != io.EOF {
+			err = end
+		}
+	}
+	c.putConv()
+	return err
+}
+
+// decodeJSONNext reads the next value from t into rv
+func (c *Conv) decodeJSONNext(t *JSONTokenizer, rv reflect.Value) error {
+	tok, err := t.Next()
+	if err == io.EOF {
+		return t.errEnd()
+	}
+	if err != nil {
+		return err
+	}
+	return c.decodeJSONToken(t, tok, rv, false)
+}
+
+// decodeJSONToken decodes the value starting with tok into rv.
+// asString applies the ",string" tag option to numbers and booleans.
+func (c *Conv) decodeJSONToken(t *JSONTokenizer, tok JSONToken, rv reflect.Value, asString bool) error {
+	if tok.Kind == K.Invalid { // null
+		switch rv.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+			rv.Set(reflect.Zero(rv.Type()))
+		}
+		return nil
+	}
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		rv = rv.Elem()
+	}
+
+	// Types with an UnmarshalJSON method receive the raw value (e.g. time.Time)
+	if rv.CanAddr() && rv.Addr().Type().NumMethod() > 0 && rv.Addr().CanInterface() {
+		if u, ok := rv.Addr().Interface().(interface{ UnmarshalJSON([]byte) error }); ok {
+			if err := t.skipJSON(tok); err != nil {
+				return err
+			}
+			return u.UnmarshalJSON([]byte(t.data[tok.Offset:t.pos]))
+		}
+	}
+
+	if rv.Kind() == reflect.Interface && rv.NumMethod() == 0 {
+		val, err := c.decodeJSONAny(t, tok)
+		if err != nil {
+			return err
+		}
+		if val == nil {
+			rv.Set(reflect.Zero(rv.Type()))
+		} else {
+			rv.Set(reflect.ValueOf(val))
+		}
+		return nil
+	}
+
+	switch tok.Kind {
+	case K.Map:
+		switch rv.Kind() {
+		case reflect.Struct:
+			return c.decodeJSONStruct(t, rv)
+		case reflect.Map:
+			return c.decodeJSONMap(t, rv)
+		}
+	case K.Slice:
+		if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+			return c.decodeJSONArray(t, rv)
+		}
+	default:
+		return c.decodeJSONScalar(tok, rv, asString)
+	}
+	return jsonTypeErr(tok, rv)
+}
+
+// decodeJSONScalar decodes a string, number or boolean token into rv
+func (c *Conv) decodeJSONScalar(tok JSONToken, rv reflect.Value, asString bool) error {
+	if asString && tok.Kind == K.String && rv.Kind() != reflect.String {
+		// ",string" option: the number or boolean is quoted
+		tok.Kind = K.Float64
+		if tok.Value == "true" || tok.Value == "false" {
+			tok.Kind = K.Bool
+		}
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		if tok.Kind == K.String {
+			rv.SetString(tok.Value)
+			return nil
+		}
+	case reflect.Bool:
+		if tok.Kind == K.Bool {
+			rv.SetBool(tok.Value == "true")
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if tok.Kind == K.Float64 {
+			n, err := c.parseJSONInt(tok, rv, true)
+			if err != nil {
+				return err
+			}
+			if rv.OverflowInt(n) {
+				return jsonErr(ErrRange, tok.Offset, D.Number, D.Overflow, tok.Value)
+			}
+			rv.SetInt(n)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if tok.Kind == K.Float64 {
+			n, err := c.parseJSONInt(tok, rv, false)
+			if err != nil {
+				return err
+			}
+			if rv.OverflowUint(uint64(n)) {
+				return jsonErr(ErrRange, tok.Offset, D.Number, D.Overflow, tok.Value)
+			}
+			rv.SetUint(uint64(n))
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if tok.Kind == K.Float64 {
+			bits := 64
+			if rv.Kind() == reflect.Float32 {
+				bits = 32
+			}
+			f, err := c.parseJSONFloat(tok, bits)
+			if err != nil {
+				return err
+			}
+			rv.SetFloat(f)
+			return nil
+		}
+	case reflect.Slice:
+		if tok.Kind == K.String && rv.Type().Elem().Kind() == reflect.Uint8 {
+			b, ok := decodeBase64(tok.Value)
+			if !ok {
+				return jsonErr(ErrSyntax, tok.Offset, D.Format, D.Invalid, "base64")
+			}
+			rv.SetBytes(b)
+			return nil
+		}
+	}
+	return jsonTypeErr(tok, rv)
+}
+
+// decodeJSONAny decodes the value starting with tok into map[string]any,
+// []any, string, float64, bool or nil
+func (c *Conv) decodeJSONAny(t *JSONTokenizer, tok JSONToken) (any, error) {
+	switch tok.Kind {
+	case K.Map:
+		m := map[string]any{}
+		for {
+			key, err := t.Next()
+			if err != nil || key.Kind == K.Map { // '}'
+				return m, err
+			}
+			val, err := t.Next()
+			if err != nil {
+				return nil, err
+			}
+			if m[key.Value], err = c.decodeJSONAny(t, val); err != nil {
+				return nil, err
+			}
+		}
+	case K.Slice:
+		list := []any{}
+		for {
+			item, err := t.Next()
+			if err != nil || item.Kind == K.Slice && item.Delim == ']' {
+				return list, err
+			}
+			val, err := c.decodeJSONAny(t, item)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, val)
+		}
+	case K.String:
+		return tok.Value, nil
+	case K.Bool:
+		return tok.Value == "true", nil
+	case K.Float64:
+		f, err := c.parseJSONFloat(tok, 64)
+		return f, err
+	}
+	return nil, nil // null
+}
+
+// decodeJSONStruct decodes the members of an object into the fields of rv
+func (c *Conv) decodeJSONStruct(t *JSONTokenizer, rv reflect.Value) error {
+	fields := jsonFields(rv.Type())
+	for {
+		key, err := t.Next()
+		if err != nil || key.Kind == K.Map { // '}'
+			return err
+		}
+		val, err := t.Next()
+		if err != nil {
+			return err
+		}
+		f := findJSONField(fields, key.Value)
+		var fv reflect.Value
+		if f != nil {
+			fv = jsonFieldValue(rv, f.index)
+		}
+		if !fv.IsValid() {
+			if err := t.skipJSON(val); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := c.decodeJSONToken(t, val, fv, f.asString); err != nil {
+			return err
+		}
+	}
+}
+
+// decodeJSONMap decodes the members of an object into a map with string or
+// integer keys
+func (c *Conv) decodeJSONMap(t *JSONTokenizer, rv reflect.Value) error {
+	mt := rv.Type()
+	switch mt.Key().Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	default:
+		return GetConv().wrErrKind(ErrUnsupportedType, D.Type, D.Not, D.Supported, mt.String()).releaseErr()
+	}
+	if rv.IsNil() {
+		rv.Set(reflect.MakeMap(mt))
+	}
+	for {
+		key, err := t.Next()
+		if err != nil || key.Kind == K.Map { // '}'
+			return err
+		}
+		kv := reflect.New(mt.Key()).Elem()
+		if kv.Kind() == reflect.String {
+			kv.SetString(key.Value)
+		} else if err := c.decodeJSONScalar(key, kv, true); err != nil {
+			return err
+		}
+		ev := reflect.New(mt.Elem()).Elem()
+		if err := c.decodeJSONNext(t, ev); err != nil {
+			return err
+		}
+		rv.SetMapIndex(kv, ev)
+	}
+}
+
+// decodeJSONArray decodes the elements of an array into a slice or array
+func (c *Conv) decodeJSONArray(t *JSONTokenizer, rv reflect.Value) error {
+	i := 0
+	for ; ; i++ {
+		item, err := t.Next()
+		if err != nil {
+			return err
+		}
+		if item.Kind == K.Slice && item.Delim == ']' {
+			break
+		}
+		if rv.Kind() == reflect.Slice && i >= rv.Len() {
+			rv.Set(reflect.Append(rv, reflect.Zero(rv.Type().Elem())))
+		}
+		if i >= rv.Len() { // Array full: ignore the rest
+			if err := t.skipJSON(item); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := c.decodeJSONToken(t, item, rv.Index(i), false); err != nil {
+			return err
+		}
+	}
+	if rv.Kind() == reflect.Array {
+		for ; i < rv.Len(); i++ {
+			rv.Index(i).Set(reflect.Zero(rv.Type().Elem()))
+		}
+	} else if rv.IsNil() {
+		rv.Set(reflect.MakeSlice(rv.Type(), 0, 0)) // [] is not null
+	} else {
+		rv.SetLen(i)
+	}
+	return nil
+}
+
+// parseJSONInt parses an integer token for rv with parseIntString
+func (c *Conv) parseJSONInt(tok JSONToken, rv reflect.Value, signed bool) (int64, error) {
+	s := tok.Value
+	digits := s
+	if s[0] == '-' {
+		if !signed {
+			return 0, jsonErr(ErrRange, tok.Offset, D.Number, D.Negative, D.Not, D.Allowed)
+		}
+		digits = s[1:]
+	}
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return 0, jsonTypeErr(tok, rv) // 1.5 or 1e3
+		}
+	}
+	// parseIntString wraps around on overflow: compare with the limit first
+	limit := "9223372036854775807"
+	if !signed {
+		limit = "18446744073709551615"
+	} else if s[0] == '-' {
+		limit = "9223372036854775808"
+	}
+	if len(digits) > len(limit) || (len(digits) == len(limit) && digits > limit) {
+		return 0, jsonErr(ErrRange, tok.Offset, D.Number, D.Overflow, s)
+	}
+	c.ResetBuffer(BuffErr)
+	n := c.parseIntString(s, 10, signed)
+	if c.hasContent(BuffErr) {
+		c.ResetBuffer(BuffErr)
+		c.wrapped = c.wrapped[:0]
+		return 0, jsonErr(ErrSyntax, tok.Offset, D.Number, D.Invalid, s)
+	}
+	return n, nil
+}
+
+// parseJSONFloat parses a number token to the nearest float of the given bit
+// size; values beyond its range are an overflow error
+func (c *Conv) parseJSONFloat(tok JSONToken, bits int) (float64, error) {
+	f, ok := parseFloatExact(tok.Value, bits)
+	if !ok {
+		return 0, jsonErr(ErrRange, tok.Offset, D.Number, D.Overflow, tok.Value)
+	}
+	return f, nil
+}
+
+// skipJSON reads past the value starting with tok
+func (t *JSONTokenizer) skipJSON(tok JSONToken) error {
+	if (tok.Kind != K.Map && tok.Kind != K.Slice) || (tok.Delim != '{' && tok.Delim != '[') {
+		return nil
+	}
+	for depth := 1; depth > 0; {
+		next, err := t.Next()
+		if err == io.EOF {
+			return t.errEnd()
+		}
+		if err != nil {
+			return err
+		}
+		switch next.Delim {
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+		}
+	}
+	return nil
+}
+
+// jsonTypeErr reports a JSON value that does not fit the Go target
+func jsonTypeErr(tok JSONToken, rv reflect.Value) error {
+	found := "string"
+	switch tok.Kind {
+	case K.Map:
+		found = "object"
+	case K.Slice:
+		found = "array"
+	case K.Float64:
+		found = "number " + tok.Value
+	case K.Bool:
+		found = "bool"
+	}
+	return jsonErr(nil, tok.Offset, D.Type, D.Mismatch, found, rv.Type().String())
+}
+
+// findJSONField returns the field named key, matching case-insensitively
+// when there is no exact match
+func findJSONField(fields []jsonField, key string) *jsonField {
+	var fold *jsonField
+	for i := range fields {
+		if fields[i].name == key {
+			return &fields[i]
+		}
+		if fold == nil && equalFoldASCII(fields[i].name, key) {
+			fold = &fields[i]
+		}
+	}
+	return fold
+}
+
+// jsonFieldValue returns the field at index, allocating nil embedded pointers.
+// Returns an invalid Value when the field cannot be set.
+func jsonFieldValue(rv reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				if !rv.CanSet() {
+					return reflect.Value{} // Unexported embedded pointer
+				}
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	if !rv.CanSet() {
+		return reflect.Value{}
+	}
+	return rv
+}
+
+// equalFoldASCII reports whether a and b are equal ignoring ASCII case
+func equalFoldASCII(a, b string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := 0; i < len(a); i++ {
+		x, y := a[i], b[i]
+		if x >= 'A' && x <= 'Z' {
+			x += 'a' - 'A'
+		}
+		if y >= 'A' && y <= 'Z' {
+			y += 'a' - 'A'
+		}
+		if x != y {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeBase64 decodes standard base64 with padding, the []byte form of JSON
+func decodeBase64(s string) ([]byte, bool) {
+	if len(s)%4 != 0 {
+		return nil, false
+	}
+	out := make([]byte, 0, len(s)/4*3)
+	for i := 0; i < len(s); i += 4 {
+		var n uint32
+		pad := 0
+		for j := 0; j < 4; j++ {
+			ch := s[i+j]
+			var v int
+			if ch == '=' && i+4 == len(s) && j >= 2 {
+				pad++
+			} else if pad > 0 {
+				return nil, false
+			} else if v = indexByte(base64Std, ch); v < 0 {
+				return nil, false
+			}
+			n = n<<6 | uint32(v)
+		}
+		out = append(out, byte(n>>16), byte(n>>8), byte(n))
+		out = out[:len(out)-pad]
+	}
+	return out, true
+}
+
+// indexByte returns the index of b in s, or -1
+func indexByte(s string, b byte) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] == b {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,322 @@
+package fmt
+
+import (
+	"io"
+	"strconv"
+	"testing"
+)
+
+func TestJSONTokenizer(t *testing.T) {
+	tz := NewJSONTokenizer(` {"id": 1, "tags": ["a\n", true, null], "n": -2.5e3} `)
+	want := []JSONToken{
+		{Kind: K.Map, Delim: '{', Offset: 1},
+		{Kind: K.String, Value: "id", Offset: 2},
+		{Kind: K.Float64, Value: "1", Offset: 8},
+		{Kind: K.String, Value: "tags", Offset: 11},
+		{Kind: K.Slice, Delim: '[', Offset: 19},
+		{Kind: K.String, Value: "a\n", Offset: 20},
+		{Kind: K.Bool, Value: "true", Offset: 27},
+		{Kind: K.Invalid, Offset: 33},
+		{Kind: K.Slice, Delim: ']', Offset: 37},
+		{Kind: K.String, Value: "n", Offset: 40},
+		{Kind: K.Float64, Value: "-2.5e3", Offset: 45},
+		{Kind: K.Map, Delim: '}', Offset: 51},
+	}
+	for i, w := range want {
+		tok, err := tz.Next()
+		if err != nil {
+			t.Fatalf("token %d: unexpected error %v", i, err)
+		}
+		if tok != w {
+			t.Errorf("token %d: expected %+v, got %+v", i, w, tok)
+		}
+	}
+	if _, err := tz.Next(); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestJSONTokenizerStrings(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`"plain"`, "plain"},
+		{`"q\"b\\s\/"`, `q"b\s/`},
+		{`"\b\f\r\t"`, "\b\f\r\t"},
+		{`"\u00e9\u4E2D"`, "\u00e9\u4e2d"},
+		{`"\ud83d\ude00"`, "\U0001F600"},
+		{`"\ud83d"`, "\ufffd"},
+		{`"\u00f1and\u00fa x"`, "\u00f1and\u00fa x"},
+	}
+	for _, tt := range tests {
+		tok, err := NewJSONTokenizer(tt.in).Next()
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.in, err)
+			continue
+		}
+		if tok.Value != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.in, tt.want, tok.Value)
+		}
+	}
+}
+
+func TestJSONTokenizerErrors(t *testing.T) {
+	tests := []struct {
+		in     string
+		offset int
+	}{
+		{`{"a" 1}`, 5},
+		{`[1,]`, 3},
+		{`[1 2]`, 3},
+		{`{"a":1]`, 6},
+		{`{1:2}`, 1},
+		{`tru`, 3},
+		{`nul!`, 3},
+		{`[01]`, 2},
+		{`-`, 1},
+		{`1.`, 2},
+		{`1e+`, 3},
+		{`"abc`, 4},
+		{`"a\qb"`, 3},
+		{"\"a\nb\"", 2},
+		{`[1, 2`, 5},
+		{`@`, 0},
+		{Convert("[").Repeat(jsonMaxDepth + 1).String(), jsonMaxDepth},
+	}
+	for _, tt := range tests {
+		tz := NewJSONTokenizer(tt.in)
+		var err error
+		for err == nil {
+			_, err = tz.Next()
+		}
+		if err == io.EOF {
+			t.Errorf("%s: expected syntax error, got io.EOF", tt.in)
+			continue
+		}
+		e := err.(*Error)
+		if off, _ := e.Field("offset"); off != tt.offset {
+			t.Errorf("%s: expected offset %d, got %v (%v)", tt.in, tt.offset, off, err)
+		}
+		if e.Type() != Msg.Parse || !Is(err, ErrSyntax) {
+			t.Errorf("%s: expected Msg.Parse wrapping ErrSyntax, got %v %v", tt.in, e.Type(), err)
+		}
+	}
+}
+
+func TestDecodeJSONDepth(t *testing.T) {
+	var v any
+	deep := Convert("[").Repeat(jsonMaxDepth).String() + Convert("]").Repeat(jsonMaxDepth).String()
+	if err := Convert(deep).DecodeJSON(&v); err != nil {
+		t.Fatalf("depth %d: unexpected error %v", jsonMaxDepth, err)
+	}
+
+	// Must fail cleanly instead of overflowing the stack
+	err := Convert(Convert("[").Repeat(5000000).String()).DecodeJSON(&v)
+	if !Is(err, ErrSyntax) {
+		t.Fatalf("expected ErrSyntax, got %v", err)
+	}
+	if off, _ := err.(*Error).Field("offset"); off != jsonMaxDepth {
+		t.Errorf("expected offset %d, got %v", jsonMaxDepth, off)
+	}
+	var m map[string]any
+	if err := Convert(Convert(`{"a":`).Repeat(jsonMaxDepth + 1).String()).DecodeJSON(&m); !Is(err, ErrSyntax) {
+		t.Errorf("objects: expected ErrSyntax, got %v", err)
+	}
+}
+
+type jsonStamp struct{ raw string }
+
+func (s *jsonStamp) UnmarshalJSON(b []byte) error {
+	s.raw = string(b)
+	return nil
+}
+
+func TestDecodeJSONStruct(t *testing.T) {
+	in := `{"id":7,"NAME":"Ana","age":"30","tags":["admin","dev"],
+		"address":{"city":"Quito","zip":"170150"},"meta":{"x":1,"y":[true]},
+		"password":"ignored","score":9.5,"active":true,"extra":{"k":"v"},
+		"notag":3,"unknown":{"deep":[1,{"a":null}]}}`
+	var u jsonUser
+	if err := Convert(in).DecodeJSON(&u); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if u.ID != 7 || u.Name != "Ana" || u.Age != 30 || u.Score != 9.5 || !u.Active || u.NoTag != 3 {
+		t.Errorf("unexpected scalar fields: %+v", u)
+	}
+	if len(u.Tags) != 2 || u.Tags[1] != "dev" {
+		t.Errorf("unexpected tags: %v", u.Tags)
+	}
+	if u.Address == nil || u.Address.City != "Quito" || u.Address.Zip != "170150" {
+		t.Errorf("unexpected address: %+v", u.Address)
+	}
+	if u.Meta["x"] != 1.0 || u.Extra["k"] != "v" || u.Password != "" {
+		t.Errorf("unexpected maps: %v %v %q", u.Meta, u.Extra, u.Password)
+	}
+	if list, ok := u.Meta["y"].([]any); !ok || list[0] != true {
+		t.Errorf("unexpected meta y: %v", u.Meta["y"])
+	}
+
+	// Round trip with the encoder
+	var back jsonUser
+	if err := Convert(JSON(u).String()).DecodeJSON(&back); err != nil {
+		t.Fatalf("round trip: %v", err)
+	}
+	if JSON(back).String() != JSON(u).String() {
+		t.Errorf("round trip mismatch:\n%s\n%s", JSON(u).String(), JSON(back).String())
+	}
+
+	var s struct {
+		When  jsonStamp `json:"when"`
+		Bytes []byte    `json:"bytes"`
+		Ptr   *int      `json:"ptr"`
+		Arr   [2]int    `json:"arr"`
+		Keys  map[int]bool
+	}
+	in = `{"when":{"t":[1]},"bytes":"aGVsbG8=","ptr":5,"arr":[1,2,3],"Keys":{"10":true}}`
+	if err := Convert(in).DecodeJSON(&s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.When.raw != `{"t":[1]}` || string(s.Bytes) != "hello" || s.Ptr == nil || *s.Ptr != 5 ||
+		s.Arr != [2]int{1, 2} || !s.Keys[10] {
+		t.Errorf("unexpected values: %+v", s)
+	}
+}
+
+func TestDecodeJSONValues(t *testing.T) {
+	var a any
+	if err := Convert(`{"a":[1,"x",null,false],"b":{}}`).DecodeJSON(&a); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m := a.(map[string]any)
+	list := m["a"].([]any)
+	if len(list) != 4 || list[0] != 1.0 || list[1] != "x" || list[2] != nil || list[3] != false {
+		t.Errorf("unexpected list: %v", list)
+	}
+
+	floats := []struct {
+		in   string
+		want float64
+	}{
+		{"0", 0}, {"-12", -12}, {"3.25", 3.25}, {"1e3", 1000}, {"2.5E-2", 0.025}, {"-1e+2", -100},
+	}
+	for _, tt := range floats {
+		var f float64
+		if err := Convert(tt.in).DecodeJSON(&f); err != nil || f != tt.want {
+			t.Errorf("%s: expected %v, got %v (%v)", tt.in, tt.want, f, err)
+		}
+	}
+
+	var n int64
+	if err := Convert("-9223372036854775808").DecodeJSON(&n); err != nil || n != -9223372036854775808 {
+		t.Errorf("min int64: got %d (%v)", n, err)
+	}
+	var u64 uint64
+	if err := Convert("18446744073709551615").DecodeJSON(&u64); err != nil || u64 != 18446744073709551615 {
+		t.Errorf("max uint64: got %d (%v)", u64, err)
+	}
+	var empty []int
+	if err := Convert("[]").DecodeJSON(&empty); err != nil || empty == nil || len(empty) != 0 {
+		t.Errorf("empty array: got %#v (%v)", empty, err)
+	}
+	p := &n
+	if err := Convert("null").DecodeJSON(&p); err != nil || p != nil {
+		t.Errorf("null pointer: got %v (%v)", p, err)
+	}
+}
+
+func TestDecodeJSONErrors(t *testing.T) {
+	var u jsonUser
+	var i8 int8
+	var ui uint
+	var i int
+	var f32 float32
+	tests := []struct {
+		name   string
+		in     string
+		target any
+		offset int
+		kind   *Error
+	}{
+		{"syntax", `{"age":}`, &u, 7, ErrSyntax},
+		{"trailing", `{} x`, &u, 3, ErrSyntax},
+		{"empty", `  `, &u, 2, ErrSyntax},
+		{"overflow", `300`, &i8, 0, ErrRange},
+		{"int64 overflow", `9223372036854775808`, &i, 0, ErrRange},
+		{"negative uint", `-1`, &ui, 0, ErrRange},
+		{"float32 overflow", `1e39`, &f32, 0, ErrRange},
+		{"type mismatch", `{"name":5}`, &u, 8, nil},
+		{"fraction to int", `1.5`, &i, 0, nil},
+	}
+	for _, tt := range tests {
+		err := Convert(tt.in).DecodeJSON(tt.target)
+		if err == nil {
+			t.Errorf("%s: expected error", tt.name)
+			continue
+		}
+		e := err.(*Error)
+		if off, _ := e.Field("offset"); off != tt.offset {
+			t.Errorf("%s: expected offset %d, got %v (%v)", tt.name, tt.offset, off, err)
+		}
+		if e.Type() != Msg.Parse {
+			t.Errorf("%s: expected Msg.Parse, got %v", tt.name, e.Type())
+		}
+		if tt.kind != nil && !Is(err, tt.kind) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.kind, err)
+		}
+	}
+
+	if err := Convert(`{"age":}`).DecodeJSON(&u); err.Error() != "Character Invalid '}' offset=7" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+	if err := Convert(`1`).DecodeJSON(i); !Is(err, ErrUnsupportedType) {
+		t.Errorf("non-pointer: expected ErrUnsupportedType, got %v", err)
+	}
+}
+
+func TestDecodeJSONFloatExact(t *testing.T) {
+	inputs := []string{
+		"1.7976931348623157e308", // Largest float64
+		"5e-324", "4.94e-324",    // Smallest denormal
+		"2.2250738585072014e-308", // Smallest normal
+		"0.000001", "1.5e300", "0.1", "-0.3", "3.141592653589793",
+		"9007199254740993", // 2^53+1, halfway between two floats
+		"123456789012345678901234567890",
+		"1.00000000000000011102230246251565404236316680908203125",
+		"2.4703282292062328e-324", // Just above half the smallest denormal
+		"1e-400", "0", "-0",
+	}
+	for _, in := range inputs {
+		want, err := strconv.ParseFloat(in, 64)
+		if err != nil {
+			t.Fatalf("strconv %s: %v", in, err)
+		}
+		var got float64
+		if err := Convert(in).DecodeJSON(&got); err != nil {
+			t.Errorf("%s: unexpected error %v", in, err)
+			continue
+		}
+		if got != want || (got == 0 && (1/got > 0) != (1/want > 0)) {
+			t.Errorf("%s: got %v want %v", in, got, want)
+		}
+	}
+
+	for _, in := range []string{"3.4028235e38", "1.401298464324817e-45", "0.1", "16777217"} {
+		want, _ := strconv.ParseFloat(in, 32)
+		var got float32
+		if err := Convert(in).DecodeJSON(&got); err != nil || got != float32(want) {
+			t.Errorf("float32 %s: got %v, %v want %v", in, got, err, want)
+		}
+	}
+
+	for _, in := range []string{"1e309", "-1.8e308"} {
+		var f float64
+		if err := Convert(in).DecodeJSON(&f); !Is(err, ErrRange) {
+			t.Errorf("%s: expected ErrRange, got %v", in, err)
+		}
+	}
+	var f32 float32
+	if err := Convert("3.5e38").DecodeJSON(&f32); !Is(err, ErrRange) {
+		t.Errorf("float32 3.5e38: expected ErrRange, got %v", err)
+	}
+}
@@ -0,0 +1,247 @@
+package fmt
+
+import (
+	"strconv"
+	"testing"
+)
+
+type jsonAddress struct {
+	City string `json:"city"`
+	Zip  string `json:"zip,omitempty"`
+}
+
+type jsonBase struct {
+	ID int `json:"id"`
+}
+
+type jsonUser struct {
+	jsonBase
+	Name     string            `json:"name"`
+	Email    string            `json:"email,omitempty"`
+	Age      int               `json:"age,string"`
+	Tags     []string          `json:"tags"`
+	Address  *jsonAddress      `json:"address"`
+	Meta     map[string]any    `json:"meta,omitempty"`
+	Password string            `json:"-"`
+	Score    float64           `json:"score"`
+	Active   bool              `json:"active"`
+	Extra    map[string]string `json:"extra,omitempty"`
+	NoTag    uint8
+	private  string
+}
+
+type jsonLevel int
+
+func (l jsonLevel) MarshalJSON() ([]byte, error) {
+	return []byte(`"level-` + Convert(int(l)).String() + `"`), nil
+}
+
+func TestJSONValues(t *testing.T) {
+	tests := []struct {
+		name string
+		in   any
+		want string
+	}{
+		{"nil", nil, "null"},
+		{"string", "hi", `"hi"`},
+		{"escapes", "a\"b\\c\nd\te\x01", `"a\"b\\c\nd\te\u0001"`},
+		{"backspace formfeed", "a\bb\fc", `"a\bb\fc"`},
+		{"html", "<a>&", `"\u003ca\u003e\u0026"`},
+		{"unicode", "ñ 😀", `"ñ 😀"`},
+		{"line separator", "a\u2028b", `"a\u2028b"`},
+		{"invalid utf8", "a\xffb", `"a\ufffdb"`},
+		{"bool", true, "true"},
+		{"int", -42, "-42"},
+		{"int8", int8(-8), "-8"},
+		{"uint64", uint64(18446744073709551615), "18446744073709551615"},
+		{"float", 3.25, "3.25"},
+		{"float32", float32(0.5), "0.5"},
+		{"float large", 1e21, "1e+21"},
+		{"float small", 1.5e-7, "1.5e-7"},
+		{"float pi", 3.141592653589793, "3.141592653589793"},
+		{"float third", 1.0 / 3, "0.3333333333333333"},
+		{"float below 1e21", 1e20, "100000000000000000000"},
+		{"float max", 1.7976931348623157e308, "1.7976931348623157e+308"},
+		{"float min denormal", 5e-324, "5e-324"},
+		{"float32 tenth", float32(0.1), "0.1"},
+		{"float32 large", float32(3.4e38), "3.4e+38"},
+		{"bytes", []byte("hello"), `"aGVsbG8="`},
+		{"bytes nil", []byte(nil), "null"},
+		{"strings", []string{"a", "b"}, `["a","b"]`},
+		{"any slice", []any{1, "x", nil, false}, `[1,"x",null,false]`},
+		{"int slice", []int{1, 2, 3}, "[1,2,3]"},
+		{"nil slice", []int(nil), "null"},
+		{"array", [2]bool{true, false}, "[true,false]"},
+		{"map sorted", map[string]int{"b": 2, "a": 1, "c": 3}, `{"a":1,"b":2,"c":3}`},
+		{"map int keys", map[int]string{10: "x", 2: "y"}, `{"10":"x","2":"y"}`},
+		{"nested", map[string]any{"list": []any{map[string]any{"k": 1.5}}}, `{"list":[{"k":1.5}]}`},
+		{"pointer", &jsonAddress{City: "Lima"}, `{"city":"Lima"}`},
+		{"nil pointer", (*jsonAddress)(nil), "null"},
+		{"marshaler", jsonLevel(3), `"level-3"`},
+		{"marshaler field", struct {
+			L jsonLevel `json:"l"`
+		}{2}, `{"l":"level-2"}`},
+		{"locstr", D.Format, `"Format"`},
+	}
+	for _, tt := range tests {
+		out, err := JSON(tt.in).StringErr()
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+			continue
+		}
+		if out != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, out)
+		}
+	}
+}
+
+func TestJSONStruct(t *testing.T) {
+	u := jsonUser{
+		jsonBase: jsonBase{ID: 7},
+		Name:     "Ana",
+		Age:      30,
+		Tags:     []string{"admin"},
+		Address:  &jsonAddress{City: "Quito", Zip: "170150"},
+		Password: "secret",
+		Score:    9.5,
+		Active:   true,
+		NoTag:    1,
+		private:  "hidden",
+	}
+	want := `{"id":7,"name":"Ana","age":"30","tags":["admin"],"address":{"city":"Quito","zip":"170150"},"score":9.5,"active":true,"NoTag":1}`
+	if out := JSON(u).String(); out != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, out)
+	}
+
+	u.Address = nil
+	u.Meta = map[string]any{"x": 1}
+	want = `{"id":7,"name":"Ana","age":"30","tags":["admin"],"address":null,"meta":{"x":1},"score":9.5,"active":true,"NoTag":1}`
+	if out := JSON(&u).String(); out != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, out)
+	}
+}
+
+type jsonNode struct {
+	Name string    `json:"name"`
+	Next *jsonNode `json:"next"`
+}
+
+func jsonSelfRef() *jsonNode {
+	n := &jsonNode{Name: "a"}
+	n.Next = &jsonNode{Name: "b", Next: n}
+	return n
+}
+
+func jsonSelfMap() map[string]any {
+	m := map[string]any{}
+	m["self"] = m
+	return m
+}
+
+func jsonSelfSlice() []any {
+	s := []any{1, nil}
+	s[1] = s
+	return s
+}
+
+type jsonInner struct {
+	Name string `json:"name"`
+	Note string
+	Tie  string
+	Kept string `json:"Kept"`
+}
+
+type jsonOther struct {
+	Tie  string
+	Kept string
+}
+
+type jsonShadow struct {
+	jsonInner
+	jsonOther
+	Name string `json:"name"`
+}
+
+type jsonLoop struct {
+	*jsonLoop
+	ID int `json:"id"`
+}
+
+func TestJSONEmbeddedShadowing(t *testing.T) {
+	v := jsonShadow{
+		jsonInner: jsonInner{Name: "in", Note: "n", Tie: "t1", Kept: "k1"},
+		jsonOther: jsonOther{Tie: "t2", Kept: "k2"},
+		Name:      "out",
+	}
+	// Outer name wins, tagged Kept wins over untagged, Tie is ambiguous
+	want := `{"Note":"n","Kept":"k1","name":"out"}`
+	if out := JSON(v).String(); out != want {
+		t.Errorf("expected %s, got %s", want, out)
+	}
+
+	var back jsonShadow
+	if err := Convert(`{"name":"x","kept":"k","Tie":"t","Note":"m"}`).DecodeJSON(&back); err != nil {
+		t.Fatal(err)
+	}
+	if back.Name != "x" || back.jsonInner.Name != "" || back.jsonInner.Kept != "k" ||
+		back.jsonOther.Kept != "" || back.jsonInner.Tie != "" || back.jsonOther.Tie != "" || back.Note != "m" {
+		t.Errorf("unexpected decode %+v", back)
+	}
+
+	// A struct embedding a pointer to itself is not expanded forever
+	loop := jsonLoop{ID: 1, jsonLoop: &jsonLoop{ID: 2}}
+	if out := JSON(loop).String(); out != `{"id":1}` {
+		t.Errorf("self embedding: got %s", out)
+	}
+}
+
+func TestJSONErrors(t *testing.T) {
+	nan := 0.0
+	nan = nan / nan
+	tests := []struct {
+		name string
+		in   any
+	}{
+		{"chan", make(chan int)},
+		{"func in slice", []any{1, func() {}}},
+		{"nan", nan},
+		{"complex map key", map[float64]int{1: 1}},
+		{"pointer cycle", jsonSelfRef()},
+		{"map cycle", jsonSelfMap()},
+		{"slice cycle", jsonSelfSlice()},
+	}
+	for _, tt := range tests {
+		out, err := JSON(tt.in).StringErr()
+		if err == nil {
+			t.Errorf("%s: expected error, got %q", tt.name, out)
+			continue
+		}
+		if !Is(err, ErrUnsupportedType) {
+			t.Errorf("%s: expected ErrUnsupportedType, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestJSONFloatRoundTrip(t *testing.T) {
+	values := []float64{3.141592653589793, 1.0 / 3, 0.1, 1e-7, 123456.789, 1.7976931348623157e308, 5e-324, 2.2250738585072014e-308, 9007199254740993}
+	for _, f := range values {
+		out := JSON(f).String()
+		if back, err := strconv.ParseFloat(out, 64); err != nil || back != f {
+			t.Errorf("%v: %s does not round-trip with strconv", f, out)
+		}
+		var back float64
+		if err := Convert(out).DecodeJSON(&back); err != nil || back != f {
+			t.Errorf("%v: decoded %s as %v, %v", f, out, back, err)
+		}
+	}
+	for _, f := range []float32{0.1, 3.4e38, 1.4e-45, 16777216, 0.3} {
+		out := JSON(f).String()
+		if back, err := strconv.ParseFloat(out, 32); err != nil || float32(back) != f {
+			t.Errorf("float32 %v: %s does not round-trip", f, out)
+		}
+		var back float32
+		if err := Convert(out).DecodeJSON(&back); err != nil || back != f {
+			t.Errorf("float32 %v: decoded %s as %v, %v", f, out, back, err)
+		}
+	}
+}
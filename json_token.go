@@ -0,0 +1,390 @@
+package fmt
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// JSONToken is one token of a JSON document returned by JSONTokenizer.Next
+type JSONToken struct {
+	Kind   Kind   // K.Map or K.Slice for delimiters, K.String, K.Float64 for numbers, K.Bool, K.Invalid for null
+	Delim  byte   // '{', '}', '[' or ']' when Kind is K.Map or K.Slice
+	Value  string // unescaped string, number literal, "true" or "false"
+	Offset int    // byte offset of the token in the input
+}
+
+// JSONTokenizer reads a JSON document one token at a time without building
+// it in memory. Commas and colons are checked and skipped; object keys are
+// returned as K.String tokens. Several top-level values may follow each other
+// (e.g. JSON lines).
+//
+//	t := NewJSONTokenizer(`{"id":1,"tags":["a"]}`)
+//	for {
+//		tok, err := t.Next() // {  "id"  1  "tags"  [  "a"  ]  }
+//		if err == io.EOF {
+//			break
+//		}
+//		if err != nil {
+//			return err // Msg.Parse error with an "offset" field
+//		}
+//	}
+//
+// Syntax errors, including nesting deeper than 10000 levels, wrap ErrSyntax,
+// have type Msg.Parse and carry the byte offset as the "offset" field (see
+// Field).
+type JSONTokenizer struct {
+	data  string
+	pos   int
+	stack []byte // open '{' and '['
+	state uint8
+	buf   []byte // unescaped string being built
+}
+
+// jsonMaxDepth limits nesting like encoding/json, so hostile input such as
+// "[[[[..." returns an error instead of exhausting the stack of decoders
+const jsonMaxDepth = 10000
+
+// What the tokenizer expects next
+const (
+	jsValue        uint8 = iota // a value: top level, after ':' or after ',' in an array
+	jsValueOrClose              // first array element or ']'
+	jsKeyOrClose                // first object key or '}'
+	jsKey                       // object key after ','
+	jsCommaOrClose              // ',' or the closing delimiter
+)
+
+// NewJSONTokenizer returns a tokenizer reading data
+func NewJSONTokenizer(data string) *JSONTokenizer {
+	return &JSONTokenizer{data: data}
+}
+
+// Offset returns the byte offset after the last token read
+func (t *JSONTokenizer) Offset() int {
+	return t.pos
+}
+
+// More reports whether the current array or object has more elements
+func (t *JSONTokenizer) More() bool {
+	t.skipSpace()
+	return t.pos < len(t.data) && t.data[t.pos] != ']' && t.data[t.pos] != '}'
+}
+
+// Next returns the next token, or io.EOF after the last complete value
+func (t *JSONTokenizer) Next() (JSONToken, error) {
+	t.skipSpace()
+	if t.pos >= len(t.data) {
+		if len(t.stack) == 0 && t.state == jsValue {
+			return JSONToken{}, io.EOF
+		}
+		return JSONToken{}, t.errEnd()
+	}
+	b := t.data[t.pos]
+	switch t.state {
+	case jsCommaOrClose:
+		if b == ',' {
+			t.pos++
+			t.state = jsValue
+			if t.stack[len(t.stack)-1] == '{' {
+				t.state = jsKey
+			}
+			return t.Next()
+		}
+		return t.close(b)
+	case jsKeyOrClose:
+		if b == '}' {
+			return t.close(b)
+		}
+		return t.key(b)
+	case jsKey:
+		return t.key(b)
+	case jsValueOrClose:
+		if b == ']' {
+			return t.close(b)
+		}
+	}
+	return t.value(b)
+}
+
+// value reads a value starting with b
+func (t *JSONTokenizer) value(b byte) (JSONToken, error) {
+	tok := JSONToken{Offset: t.pos}
+	switch {
+	case b == '{' || b == '[':
+		if len(t.stack) >= jsonMaxDepth {
+			return tok, jsonErr(ErrSyntax, t.pos, D.Exceeds, D.Maximum, "depth", jsonMaxDepth)
+		}
+		tok.Kind, tok.Delim, t.state = K.Map, b, jsKeyOrClose
+		if b == '[' {
+			tok.Kind, t.state = K.Slice, jsValueOrClose
+		}
+		t.stack = append(t.stack, b)
+		t.pos++
+		return tok, nil
+	case b == '"':
+		s, err := t.readString()
+		if err != nil {
+			return tok, err
+		}
+		tok.Kind, tok.Value = K.String, s
+	case b == 't' || b == 'f':
+		word := "true"
+		if b == 'f' {
+			word = "false"
+		}
+		if err := t.literal(word); err != nil {
+			return tok, err
+		}
+		tok.Kind, tok.Value = K.Bool, word
+	case b == 'n':
+		if err := t.literal("null"); err != nil {
+			return tok, err
+		}
+		tok.Kind = K.Invalid
+	case b == '-' || (b >= '0' && b <= '9'):
+		if err := t.number(); err != nil {
+			return tok, err
+		}
+		tok.Kind, tok.Value = K.Float64, t.data[tok.Offset:t.pos]
+	default:
+		return tok, t.errChar()
+	}
+	t.afterValue()
+	return tok, nil
+}
+
+// key reads an object key and the colon after it
+func (t *JSONTokenizer) key(b byte) (JSONToken, error) {
+	tok := JSONToken{Kind: K.String, Offset: t.pos}
+	if b != '"' {
+		return tok, t.errChar()
+	}
+	s, err := t.readString()
+	if err != nil {
+		return tok, err
+	}
+	t.skipSpace()
+	if t.pos >= len(t.data) {
+		return tok, t.errEnd()
+	}
+	if t.data[t.pos] != ':' {
+		return tok, t.errChar()
+	}
+	t.pos++
+	t.state = jsValue
+	tok.Value = s
+	return tok, nil
+}
+
+// close reads the delimiter closing the innermost array or object
+func (t *JSONTokenizer) close(b byte) (JSONToken, error) {
+	tok := JSONToken{Kind: K.Map, Delim: b, Offset: t.pos}
+	n := len(t.stack)
+	if n == 0 || (b == '}') != (t.stack[n-1] == '{') || (b != '}' && b != ']') {
+		return tok, t.errChar()
+	}
+	if b == ']' {
+		tok.Kind = K.Slice
+	}
+	t.stack = t.stack[:n-1]
+	t.pos++
+	t.afterValue()
+	return tok, nil
+}
+
+// afterValue sets the state once a value is complete
+func (t *JSONTokenizer) afterValue() {
+	t.state = jsCommaOrClose
+	if len(t.stack) == 0 {
+		t.state = jsValue
+	}
+}
+
+// skipSpace advances over JSON whitespace
+func (t *JSONTokenizer) skipSpace() {
+	for t.pos < len(t.data) {
+		switch t.data[t.pos] {
+		case ' ', '\t', '\n', '\r':
+			t.pos++
+		default:
+			return
+		}
+	}
+}
+
+// literal reads true, false or null
+func (t *JSONTokenizer) literal(word string) error {
+	for i := 0; i < len(word); i++ {
+		if t.pos >= len(t.data) {
+			return t.errEnd()
+		}
+		if t.data[t.pos] != word[i] {
+			return t.errChar()
+		}
+		t.pos++
+	}
+	return nil
+}
+
+// number reads -?(0|[1-9][0-9]*)(.[0-9]+)?([eE][+-]?[0-9]+)?
+func (t *JSONTokenizer) number() error {
+	if t.data[t.pos] == '-' {
+		t.pos++
+	}
+	if t.pos >= len(t.data) {
+		return t.errEnd()
+	}
+	if t.data[t.pos] == '0' {
+		t.pos++
+	} else if err := t.digits(); err != nil {
+		return err
+	}
+	if t.pos < len(t.data) && t.data[t.pos] == '.' {
+		t.pos++
+		if err := t.digits(); err != nil {
+			return err
+		}
+	}
+	if t.pos < len(t.data) && (t.data[t.pos] == 'e' || t.data[t.pos] == 'E') {
+		t.pos++
+		if t.pos < len(t.data) && (t.data[t.pos] == '+' || t.data[t.pos] == '-') {
+			t.pos++
+		}
+		if err := t.digits(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// digits reads one or more decimal digits
+func (t *JSONTokenizer) digits() error {
+	start := t.pos
+	for t.pos < len(t.data) && t.data[t.pos] >= '0' && t.data[t.pos] <= '9' {
+		t.pos++
+	}
+	if t.pos == start {
+		if t.pos >= len(t.data) {
+			return t.errEnd()
+		}
+		return t.errChar()
+	}
+	return nil
+}
+
+// readString reads a quoted string starting at t.pos and returns it unescaped
+func (t *JSONTokenizer) readString() (string, error) {
+	start := t.pos + 1
+	i := start
+	// Fast path: no escapes, the result is a substring of the input
+	for i < len(t.data) && t.data[i] != '"' && t.data[i] != '\\' && t.data[i] >= 0x20 {
+		i++
+	}
+	if i < len(t.data) && t.data[i] == '"' {
+		t.pos = i + 1
+		return t.data[start:i], nil
+	}
+
+	t.buf = append(t.buf[:0], t.data[start:i]...)
+	for t.pos = i; t.pos < len(t.data); {
+		b := t.data[t.pos]
+		switch {
+		case b == '"':
+			t.pos++
+			return string(t.buf), nil
+		case b < 0x20:
+			return "", t.errChar()
+		case b != '\\':
+			t.buf = append(t.buf, b)
+			t.pos++
+			continue
+		}
+		t.pos++ // Backslash
+		if t.pos >= len(t.data) {
+			return "", t.errEnd()
+		}
+		switch e := t.data[t.pos]; e {
+		case '"', '\\', '/':
+			t.buf = append(t.buf, e)
+		case 'b':
+			t.buf = append(t.buf, '\b')
+		case 'f':
+			t.buf = append(t.buf, '\f')
+		case 'n':
+			t.buf = append(t.buf, '\n')
+		case 'r':
+			t.buf = append(t.buf, '\r')
+		case 't':
+			t.buf = append(t.buf, '\t')
+		case 'u':
+			r, err := t.readHex4()
+			if err != nil {
+				return "", err
+			}
+			// Surrogate pair, e.g. \ud83d\ude00
+			if r >= 0xD800 && r < 0xDC00 && t.pos+6 < len(t.data) && t.data[t.pos+1] == '\\' && t.data[t.pos+2] == 'u' {
+				save := t.pos
+				t.pos += 2
+				r2, err := t.readHex4()
+				if err == nil && r2 >= 0xDC00 && r2 < 0xE000 {
+					r = (r-0xD800)<<10 + (r2 - 0xDC00) + 0x10000
+				} else {
+					t.pos = save
+				}
+			}
+			if r >= 0xD800 && r < 0xE000 {
+				r = utf8.RuneError // Unpaired surrogate
+			}
+			var enc [utf8.UTFMax]byte
+			n := utf8.EncodeRune(enc[:], r)
+			t.buf = append(t.buf, enc[:n]...)
+		default:
+			return "", t.errChar()
+		}
+		t.pos++
+	}
+	return "", t.errEnd()
+}
+
+// readHex4 reads the 4 hex digits after \u; t.pos is left on the last digit
+func (t *JSONTokenizer) readHex4() (rune, error) {
+	var r rune
+	for n := 0; n < 4; n++ {
+		t.pos++
+		if t.pos >= len(t.data) {
+			return 0, t.errEnd()
+		}
+		b := t.data[t.pos]
+		switch {
+		case b >= '0' && b <= '9':
+			r = r<<4 | rune(b-'0')
+		case b >= 'a' && b <= 'f':
+			r = r<<4 | rune(b-'a'+10)
+		case b >= 'A' && b <= 'F':
+			r = r<<4 | rune(b-'A'+10)
+		default:
+			return 0, t.errChar()
+		}
+	}
+	return r, nil
+}
+
+// errChar reports the unexpected character at t.pos
+func (t *JSONTokenizer) errChar() error {
+	return jsonErr(ErrSyntax, t.pos, D.Character, D.Invalid, "'"+t.data[t.pos:t.pos+1]+"'")
+}
+
+// errEnd reports input ending inside a value
+func (t *JSONTokenizer) errEnd() error {
+	return jsonErr(ErrSyntax, t.pos, D.End, D.Of, D.Input)
+}
+
+// jsonErr builds a Msg.Parse error wrapping kind (may be nil) with the byte
+// offset as "offset" field
+func jsonErr(kind *Error, offset int, msgs ...any) *Error {
+	c := GetConv()
+	c.wrErr(msgs...)
+	if kind != nil {
+		c.wrapped = append(c.wrapped, kind)
+	}
+	return c.SetType(Msg.Parse).releaseErr().With("offset", offset)
+}
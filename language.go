@@ -33,6 +33,10 @@ func (l lang) String() string {
 	case RU:
 		return "RU"
 	default:
+		// Regional variants use their BCP-47 tag (e.g. "pt-PT")
+		if v := getVariant(l); v != nil {
+			return v.tag
+		}
 		return "EN" // fallback
 	}
 }
@@ -96,15 +100,18 @@ type LocStr [9]string
 // OutLang("ES")            // Set Spanish as default (using string code), returns "ES"
 // OutLang("fr")            // Set French as default (case-insensitive), returns "FR"
 // OutLang("en-US")         // Accepts locale strings, parses to EN, returns "EN"
+// OutLang("da, fr;q=0.9")  // Accepts Accept-Language headers, picks the best supported, returns "FR"
+// OutLang("pt-PT")         // Selects a regional variant registered with AddVariant, returns "pt-PT"
 //
 // If a string is passed, it is automatically parsed using supported codes.
 // If a lang value is passed, it is assigned directly.
 // If another type is passed, nothing happens.
 // Always returns the current language code as string (e.g. "EN", "ES", etc).
 func OutLang(l ...any) string {
-	c := GetConv()
 	if len(l) == 0 {
+		c := GetConv()
 		systemLang := c.getSystemLang() // Get system lang without holding lock
+		c.putConv()
 		defLangMu.Lock()
 		defLang = systemLang
 		result := defLang.String()
@@ -112,13 +119,8 @@ func OutLang(l ...any) string {
 		return result
 	}
 
-	var newLang lang
-	switch v := l[0].(type) {
-	case lang:
-		newLang = v
-	case string:
-		newLang = c.langParser(v)
-	default:
+	newLang, ok := parseLangArg(l[0])
+	if !ok {
 		// Return current language without changes
 		defLangMu.RLock()
 		result := defLang.String()
@@ -133,6 +135,23 @@ func OutLang(l ...any) string {
 	return result
 }
 
+// parseLangArg converts a lang constant, LangScope or string code to lang.
+// Returns false for any other type.
+func parseLangArg(v any) (lang, bool) {
+	switch l := v.(type) {
+	case lang:
+		return l, true
+	case LangScope:
+		return l.l, true
+	case string:
+		c := GetConv()
+		parsed := c.langParser(l)
+		c.putConv()
+		return parsed, true
+	}
+	return EN, false
+}
+
 // getCurrentLang returns the current default language safely
 func getCurrentLang() lang {
 	defLangMu.RLock()
@@ -141,31 +160,16 @@ func getCurrentLang() lang {
 }
 
 // langParser processes a list of language strings (e.g., from env vars or browser settings)
-// and returns the first valid language found. It centralizes the parsing logic for both
+// and returns the best supported language. Each string may be a single tag ("pt-BR",
+// "en_US.UTF-8"), an Accept-Language header ("fr-CH, fr;q=0.9, en;q=0.8") or a
+// LANGUAGE env list ("fr:de"). Strings are tried in order; within a string the
+// entries are tried by descending q weight. It centralizes the parsing logic for both
 // frontend and backend environments.
 func (c *Conv) langParser(langStrings ...string) lang {
-
-	for _, langStr := range langStrings {
-		if langStr == "" {
-			continue
-		}
-
-		// Parse language code from the string, handling common formats using internal splitStr.
-		code := c.splitStr(langStr, ".")[0] // Removes encoding, e.g., ".UTF-8"
-		code = c.splitStr(code, "_")[0]     // Handles locale format, e.g., "en_US"
-		code = c.splitStr(code, "-")[0]     // Handles standard format, e.g., "en-US"
-
-		if code == "" {
-			continue
-		}
-
-		// Inline mapLangCode logic
-		return c.mapLangCode(code)
+	if l, ok := negotiateLang(langStrings...); ok {
+		return l
 	}
-
-	// c.putConv()
-
-	return EN // Default fallback if no valid language string is found.
+	return EN // Default fallback if no supported language is found.
 }
 
 func (c *Conv) mapLangCode(strVal string) lang {
@@ -178,27 +182,35 @@ func (c *Conv) mapLangCode(strVal string) lang {
 
 	code := c.GetString(BuffWork) // Get lowercase string
 
+	if l, ok := langFromCode(code); ok {
+		return l
+	}
+	return EN // Default fallback
+}
+
+// langFromCode maps a lowercase ISO 639 code to a supported language
+func langFromCode(code string) (lang, bool) {
 	switch code {
 	// Group 1
-	case "en":
-		return EN
-	case "es":
-		return ES
-	case "zh":
-		return ZH
-	case "hi":
-		return HI
-	case "ar":
-		return AR
+	case "en", "eng":
+		return EN, true
+	case "es", "spa":
+		return ES, true
+	case "zh", "zho", "chi":
+		return ZH, true
+	case "hi", "hin":
+		return HI, true
+	case "ar", "ara":
+		return AR, true
 	// Group 2
-	case "pt":
-		return PT
-	case "fr":
-		return FR
-	case "de":
-		return DE
-	case "ru":
-		return RU
+	case "pt", "por":
+		return PT, true
+	case "fr", "fra", "fre":
+		return FR, true
+	case "de", "deu", "ger":
+		return DE, true
+	case "ru", "rus":
+		return RU, true
 	}
-	return EN // Default fallback
+	return EN, false
 }
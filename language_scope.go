@@ -0,0 +1,116 @@
+package fmt
+
+import "context"
+
+// LangScope renders output in a fixed language without touching the global
+// default set by OutLang. It is a plain value, so it can be stored per request
+// and shared between goroutines without locking.
+//
+//	es := Lang(ES)
+//	es.Translate(D.Format, D.Invalid).String() // "Formato Inválido"
+//	es.Err(D.Format, D.Invalid)                // error: "Formato Inválido"
+//	es.Fmt("%L: %d", D.Value, 42)              // "Valor: 42"
+//
+// An explicit language argument still wins: Lang(ES).Translate(FR, D.Format)
+// renders French.
+type LangScope struct {
+	l       lang
+	isolate bool // wrap embedded values in right-to-left output (see Isolated)
+}
+
+// Lang returns a language-scoped handle.
+//
+// Lang(ES)        // lang constant
+// Lang("es")      // string code (case-insensitive)
+// Lang("en-US")   // locale strings are parsed like OutLang
+// Lang(scope)     // an existing LangScope is returned unchanged
+//
+// Any other value returns a scope for the current global language.
+func Lang(l any) LangScope {
+	if s, ok := l.(LangScope); ok {
+		return s
+	}
+	if v, ok := parseLangArg(l); ok {
+		return LangScope{l: v}
+	}
+	return LangScope{l: getCurrentLang()}
+}
+
+// String returns the language code of the scope (e.g. "ES")
+func (s LangScope) String() string {
+	return s.l.String()
+}
+
+// Isolated returns a copy of the scope that isolates embedded values when its
+// language is right-to-left, so numbers, paths and Latin names do not reorder
+// the surrounding text: Translate wraps them in Unicode isolates (U+2068 …
+// U+2069) and Html in <bdi>. Errors are never wrapped, so their text stays
+// comparable and clean in logs; use Isolate there when needed.
+//
+//	Lang(AR).Isolated().Translate(D.Value, 42) // "قيمة \u206842\u2069"
+func (s LangScope) Isolated() LangScope {
+	s.isolate = true
+	return s
+}
+
+// Translate works like the package-level Translate using the scope language.
+func (s LangScope) Translate(values ...any) *Conv {
+	return GetConv().smartArgs(BuffOut, s.l, " ", true, false, s.isolate, values...)
+}
+
+// Html works like the package-level Html using the scope language.
+func (s LangScope) Html(values ...any) *Conv {
+	return GetConv().smartArgs(BuffOut, s.l, "", false, true, s.isolate, values...)
+}
+
+// Err works like the package-level Err using the scope language.
+func (s LangScope) Err(msgs ...any) *Error {
+	return GetConv().smartArgs(BuffErr, s.l, " ", true, false, false, msgs...).releaseErr()
+}
+
+// Errf works like the package-level Errf using the scope language for %L.
+func (s LangScope) Errf(format string, args ...any) *Error {
+	return GetConv().wrErrf(s.l, format, args...).releaseErr()
+}
+
+// Info works like the package-level Info using the scope language.
+func (s LangScope) Info(msgs ...any) *Conv {
+	return s.Translate(msgs...).SetType(Msg.Info)
+}
+
+// Warn works like the package-level Warn using the scope language.
+func (s LangScope) Warn(msgs ...any) *Conv {
+	return s.Translate(msgs...).SetType(Msg.Warning)
+}
+
+// Success works like the package-level Success using the scope language.
+func (s LangScope) Success(msgs ...any) *Conv {
+	return s.Translate(msgs...).SetType(Msg.Success)
+}
+
+// Fmt works like the package-level Fmt using the scope language for %L.
+func (s LangScope) Fmt(format string, args ...any) string {
+	return GetConv().wrFormat(BuffOut, s.l, format, args...).String()
+}
+
+// langCtxKey is the context key for the request language (unexported to avoid collisions)
+type langCtxKey struct{}
+
+// WithLang returns a copy of ctx carrying the given language.
+// Accepts the same values as Lang.
+//
+//	ctx = WithLang(r.Context(), r.Header.Get("Accept-Language"))
+func WithLang(ctx context.Context, l any) context.Context {
+	return context.WithValue(ctx, langCtxKey{}, Lang(l))
+}
+
+// LangFrom returns the language scope stored in ctx by WithLang.
+// If ctx carries no language, the current global language is used.
+func LangFrom(ctx context.Context) LangScope {
+	if ctx != nil {
+		if s, ok := ctx.Value(langCtxKey{}).(LangScope); ok {
+			return s
+		}
+	}
+	return LangScope{l: getCurrentLang()}
+}
@@ -0,0 +1,120 @@
+package fmt
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+func TestLangScope(t *testing.T) {
+	OutLang(EN)
+	defer OutLang(EN)
+
+	t.Run("Translate", func(t *testing.T) {
+		got := Lang(ES).Translate(D.Format, D.Invalid).String()
+		if got != "Formato Inválido" {
+			t.Errorf("expected 'Formato Inválido', got %q", got)
+		}
+	})
+
+	t.Run("string code", func(t *testing.T) {
+		got := Lang("fr-FR").Translate(D.Format).String()
+		if got != "Format" {
+			t.Errorf("expected 'Format', got %q", got)
+		}
+		if code := Lang("fr-FR").String(); code != "FR" {
+			t.Errorf("expected 'FR', got %q", code)
+		}
+	})
+
+	t.Run("explicit lang wins", func(t *testing.T) {
+		got := Lang(ES).Translate(DE, D.Value).String()
+		if got != "Wert" {
+			t.Errorf("expected 'Wert', got %q", got)
+		}
+	})
+
+	t.Run("Err", func(t *testing.T) {
+		got := Lang(ES).Err(D.Format, D.Invalid).Error()
+		if got != "Formato Inválido" {
+			t.Errorf("expected 'Formato Inválido', got %q", got)
+		}
+	})
+
+	t.Run("Errf", func(t *testing.T) {
+		got := Lang(ES).Errf("%L %d", D.Value, 5).Error()
+		if got != "Valor 5" {
+			t.Errorf("expected 'Valor 5', got %q", got)
+		}
+	})
+
+	t.Run("Fmt", func(t *testing.T) {
+		got := Lang(ES).Fmt("%L: %d", D.Value, 42)
+		if got != "Valor: 42" {
+			t.Errorf("expected 'Valor: 42', got %q", got)
+		}
+	})
+
+	t.Run("Html", func(t *testing.T) {
+		got := Lang(ES).Html("<b>", D.Value, "</b>").String()
+		if got != "<b>Valor</b>" {
+			t.Errorf("expected '<b>Valor</b>', got %q", got)
+		}
+	})
+
+	t.Run("global unchanged", func(t *testing.T) {
+		_ = Lang(RU).Translate(D.Value).String()
+		if got := Translate(D.Value).String(); got != "Value" {
+			t.Errorf("expected global EN 'Value', got %q", got)
+		}
+	})
+}
+
+func TestLangContext(t *testing.T) {
+	OutLang(EN)
+	defer OutLang(EN)
+
+	ctx := WithLang(context.Background(), "de")
+	if got := LangFrom(ctx).Translate(D.Value).String(); got != "Wert" {
+		t.Errorf("expected 'Wert', got %q", got)
+	}
+
+	if got := LangFrom(context.Background()).String(); got != "EN" {
+		t.Errorf("expected fallback 'EN', got %q", got)
+	}
+}
+
+func TestLangScopeConcurrent(t *testing.T) {
+	OutLang(EN)
+	defer OutLang(EN)
+
+	scopes := []struct {
+		scope LangScope
+		want  string
+	}{
+		{Lang(ES), "Valor"},
+		{Lang(FR), "Valeur"},
+		{Lang(DE), "Wert"},
+		{Lang(EN), "Value"},
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan string, 400)
+	for i := 0; i < 100; i++ {
+		for _, sc := range scopes {
+			wg.Add(1)
+			go func(s LangScope, want string) {
+				defer wg.Done()
+				ctx := WithLang(context.Background(), s)
+				if got := LangFrom(ctx).Translate(D.Value).String(); got != want {
+					errs <- Fmt("%s: got %q, want %q", s.String(), got, want)
+				}
+			}(sc.scope, sc.want)
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for e := range errs {
+		t.Error(e)
+	}
+}
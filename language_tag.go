@@ -0,0 +1,208 @@
+package fmt
+
+// =============================================================================
+// LANGUAGE NEGOTIATION - BCP-47 tags, Accept-Language and locale lists
+// =============================================================================
+
+// langTag holds the subtags of a BCP-47 language tag used for matching.
+// Extensions, variants and private-use subtags are ignored.
+type langTag struct {
+	lang   string // primary language, lowercase ("zh")
+	script string // script subtag, title case ("Hant"), empty if absent
+	region string // region subtag, uppercase ("TW" or "419"), empty if absent
+	q      int    // quality weight in thousandths (q=0.8 => 800)
+}
+
+// parseLangTag parses a single tag such as "zh-Hant-TW", "pt_BR.UTF-8" or "en".
+// Returns false when the primary subtag is not a 2-3 letter language code
+// (e.g. "C", "POSIX" or "*").
+func parseLangTag(s string) (langTag, bool) {
+	var t langTag
+	t.q = 1000
+
+	// Remove POSIX encoding and modifier: "en_US.UTF-8@euro" => "en_US"
+	for i := 0; i < len(s); i++ {
+		if s[i] == '.' || s[i] == '@' {
+			s = s[:i]
+			break
+		}
+	}
+
+	idx := 0
+	for start := 0; start <= len(s); {
+		end := start
+		for end < len(s) && s[end] != '-' && s[end] != '_' {
+			end++
+		}
+		sub := s[start:end]
+		start = end + 1
+
+		switch {
+		case idx == 0:
+			if (len(sub) != 2 && len(sub) != 3) || !isAlphaStr(sub) {
+				return t, false
+			}
+			t.lang = asciiCase(sub, false)
+		case len(sub) == 1:
+			// Singleton starts an extension or private use sequence: stop parsing
+			return t, true
+		case idx == 1 && len(sub) == 4 && isAlphaStr(sub):
+			t.script = asciiCase(sub[:1], true) + asciiCase(sub[1:], false)
+		case t.region == "" && len(sub) == 2 && isAlphaStr(sub):
+			t.region = asciiCase(sub, true)
+		case t.region == "" && len(sub) == 3 && isDigitStr(sub):
+			t.region = sub
+		}
+		idx++
+	}
+	return t, true
+}
+
+// parseLangList parses an Accept-Language header ("fr-CH, fr;q=0.9, en;q=0.8"),
+// a navigator.languages style list or a LANGUAGE env value ("fr:de:en").
+// Entries are returned sorted by descending q weight, keeping the original
+// order for equal weights. Entries with q=0 and invalid tags are dropped.
+func parseLangList(list string) []langTag {
+	var tags []langTag
+	for start := 0; start < len(list); {
+		end := start
+		for end < len(list) && list[end] != ',' && list[end] != ':' {
+			end++
+		}
+		entry := trimSpaceStr(list[start:end])
+		start = end + 1
+
+		q := 1000
+		for i := 0; i < len(entry); i++ {
+			if entry[i] == ';' {
+				q = parseQValue(entry[i+1:])
+				entry = trimSpaceStr(entry[:i])
+				break
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		t, ok := parseLangTag(entry)
+		if !ok {
+			continue
+		}
+		t.q = q
+
+		// Stable insertion by descending weight
+		pos := len(tags)
+		for pos > 0 && tags[pos-1].q < q {
+			pos--
+		}
+		tags = append(tags, langTag{})
+		copy(tags[pos+1:], tags[pos:])
+		tags[pos] = t
+	}
+	return tags
+}
+
+// parseQValue parses the parameter part of an Accept-Language entry ("q=0.8")
+// and returns the weight in thousandths. Unknown parameters keep q=1.
+func parseQValue(params string) int {
+	params = trimSpaceStr(params)
+	if len(params) < 2 || (params[0] != 'q' && params[0] != 'Q') || params[1] != '=' {
+		return 1000
+	}
+	v := trimSpaceStr(params[2:])
+	if len(v) == 0 || (v[0] != '0' && v[0] != '1') {
+		return 0
+	}
+	q := int(v[0]-'0') * 1000
+	if len(v) > 1 && v[1] == '.' {
+		scale := 100
+		for i := 2; i < len(v) && i < 5 && v[i] >= '0' && v[i] <= '9'; i++ {
+			q += int(v[i]-'0') * scale
+			scale /= 10
+		}
+	}
+	if q > 1000 {
+		q = 1000
+	}
+	return q
+}
+
+// matchLangTag returns the supported language for a parsed tag,
+// preferring a registered regional variant when one matches
+func matchLangTag(t langTag) (lang, bool) {
+	base, ok := langFromCode(t.lang)
+	if !ok {
+		return EN, false
+	}
+	if t.script != "" || t.region != "" {
+		if v, ok := matchVariant(base, t); ok {
+			return v, true
+		}
+	}
+	return base, true
+}
+
+// negotiateLang returns the best supported language for the given
+// preference lists, in priority order. Returns false if none is supported.
+func negotiateLang(lists ...string) (lang, bool) {
+	for _, list := range lists {
+		for _, t := range parseLangList(list) {
+			if l, ok := matchLangTag(t); ok {
+				return l, true
+			}
+		}
+	}
+	return EN, false
+}
+
+// isAlphaStr reports whether s contains only ASCII letters
+func isAlphaStr(s string) bool {
+	for i := 0; i < len(s); i++ {
+		ch := s[i] | asciiCaseDiff // fold to lowercase
+		if ch < 'a' || ch > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// isDigitStr reports whether s contains only ASCII digits
+func isDigitStr(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// asciiCase returns s with ASCII letters converted to upper or lower case.
+// Returns s itself when no change is needed (no allocation).
+func asciiCase(s string, upper bool) string {
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if (upper && ch >= 'a' && ch <= 'z') || (!upper && ch >= 'A' && ch <= 'Z') {
+			b := []byte(s)
+			for j := i; j < len(b); j++ {
+				if upper && b[j] >= 'a' && b[j] <= 'z' {
+					b[j] -= asciiCaseDiff
+				} else if !upper && b[j] >= 'A' && b[j] <= 'Z' {
+					b[j] += asciiCaseDiff
+				}
+			}
+			return string(b)
+		}
+	}
+	return s
+}
+
+// trimSpaceStr removes leading and trailing ASCII spaces and tabs
+func trimSpaceStr(s string) string {
+	start, end := 0, len(s)
+	for start < end && (s[start] == ' ' || s[start] == '\t') {
+		start++
+	}
+	for end > start && (s[end-1] == ' ' || s[end-1] == '\t') {
+		end--
+	}
+	return s[start:end]
+}
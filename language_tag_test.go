@@ -0,0 +1,86 @@
+package fmt
+
+import "testing"
+
+func TestParseLangTag(t *testing.T) {
+	tests := []struct {
+		in                   string
+		lang, script, region string
+		ok                   bool
+	}{
+		{"en", "en", "", "", true},
+		{"pt-BR", "pt", "", "BR", true},
+		{"zh-Hant-TW", "zh", "Hant", "TW", true},
+		{"ZH-hans", "zh", "Hans", "", true},
+		{"es-419", "es", "", "419", true},
+		{"en_US.UTF-8", "en", "", "US", true},
+		{"de_DE@euro", "de", "", "DE", true},
+		{"sr-Latn-RS-x-private", "sr", "Latn", "RS", true},
+		{"C", "", "", "", false},
+		{"POSIX", "", "", "", false},
+		{"*", "", "", "", false},
+		{"", "", "", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := parseLangTag(tt.in)
+		if ok != tt.ok {
+			t.Errorf("parseLangTag(%q) ok = %v, want %v", tt.in, ok, tt.ok)
+			continue
+		}
+		if ok && (got.lang != tt.lang || got.script != tt.script || got.region != tt.region) {
+			t.Errorf("parseLangTag(%q) = %+v, want %s/%s/%s", tt.in, got, tt.lang, tt.script, tt.region)
+		}
+	}
+}
+
+func TestParseLangList(t *testing.T) {
+	tags := parseLangList("da, en-GB;q=0.8, fr;q=0.9, *;q=0.5, de;q=0")
+	want := []string{"da", "fr", "en"}
+	if len(tags) != len(want) {
+		t.Fatalf("expected %d tags, got %+v", len(want), tags)
+	}
+	for i, w := range want {
+		if tags[i].lang != w {
+			t.Errorf("tag %d: expected %q, got %q", i, w, tags[i].lang)
+		}
+	}
+	if tags[1].q != 900 || tags[2].q != 800 {
+		t.Errorf("unexpected weights: %+v", tags)
+	}
+}
+
+func TestLangNegotiation(t *testing.T) {
+	c := GetConv()
+	defer c.PutConv()
+
+	tests := []struct {
+		name string
+		in   []string
+		want lang
+	}{
+		{"single tag", []string{"fr-CA"}, FR},
+		{"accept-language weights", []string{"da, en;q=0.7, de;q=0.9"}, DE},
+		{"unsupported first entry", []string{"ja-JP,pt-BR;q=0.8"}, PT},
+		{"script subtag", []string{"zh-Hant-TW"}, ZH},
+		{"navigator.languages", []string{"ko", "ru-RU", "en-US"}, RU},
+		{"LANGUAGE env list", []string{"C.UTF-8", "sv:es:en"}, ES},
+		{"three letter code", []string{"ara"}, AR},
+		{"nothing supported", []string{"ja, ko"}, EN},
+		{"empty", []string{""}, EN},
+	}
+	for _, tt := range tests {
+		if got := c.langParser(tt.in...); got != tt.want {
+			t.Errorf("%s: langParser(%q) = %v, want %v", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestOutLangAcceptLanguage(t *testing.T) {
+	defer OutLang(EN)
+	if got := OutLang("da, fr;q=0.9, en;q=0.8"); got != "FR" {
+		t.Errorf("expected 'FR', got %q", got)
+	}
+	if got := Lang("xx, hi;q=0.5").String(); got != "HI" {
+		t.Errorf("expected 'HI', got %q", got)
+	}
+}
@@ -0,0 +1,160 @@
+package fmt
+
+import "sync"
+
+// numLangs is the number of base languages, one per LocStr slot.
+// lang values from numLangs upward identify registered regional variants.
+const numLangs = lang(len(LocStr{}))
+
+// langVariant holds regional overrides on top of a base language
+type langVariant struct {
+	tag    string            // canonical tag, e.g. "pt-PT" or "zh-Hant"
+	base   lang              // base language used when no override exists
+	script string            // script subtag ("Hant"), empty if not part of the tag
+	region string            // region subtag ("PT"), empty if not part of the tag
+	words  map[string]string // English text of the LocStr => regional text
+}
+
+// Registered variants; index i corresponds to lang(numLangs + i)
+var (
+	variants   []*langVariant
+	variantsMu sync.RWMutex
+)
+
+// AddVariant registers regional overrides for a language tag such as "pt-PT",
+// "es-MX" or "zh-Hant" and returns its language value. Overrides are given as
+// LocStr/string pairs; terms without an override keep the base language text.
+// Calling AddVariant again with the same tag adds to the existing overrides.
+//
+//	PTPT := AddVariant("pt-PT", D.Files, "Ficheiros", D.Email, "Correio eletrónico")
+//	OutLang("pt-PT")              // select it globally, returns "pt-PT"
+//	Translate(PTPT, D.Files)      // "Ficheiros"
+//	Translate(PTPT, D.Format)     // "Formato" (base PT entry)
+//
+// The variant is also picked by negotiation: "pt-PT", "pt_PT.UTF-8" or an
+// Accept-Language header preferring pt-PT all select it. A "zh-Hant" variant
+// also matches zh-TW, zh-HK and zh-MO.
+//
+// Returns EN without registering anything if the tag's base language is not supported.
+func AddVariant(tag string, pairs ...any) lang {
+	t, ok := parseLangTag(tag)
+	if !ok {
+		return EN
+	}
+	base, ok := langFromCode(t.lang)
+	if !ok {
+		return EN
+	}
+
+	variantsMu.Lock()
+	defer variantsMu.Unlock()
+
+	id := -1
+	for i, v := range variants {
+		if v.base == base && v.script == t.script && v.region == t.region {
+			id = i
+			break
+		}
+	}
+	if id < 0 {
+		if int(numLangs)+len(variants) > 255 {
+			return base // lang is a uint8: no room left for more variants
+		}
+		canonical := t.lang
+		if t.script != "" {
+			canonical += "-" + t.script
+		}
+		if t.region != "" {
+			canonical += "-" + t.region
+		}
+		variants = append(variants, &langVariant{
+			tag:    canonical,
+			base:   base,
+			script: t.script,
+			region: t.region,
+			words:  make(map[string]string),
+		})
+		id = len(variants) - 1
+	}
+
+	v := variants[id]
+	for i := 0; i+1 < len(pairs); i += 2 {
+		text, ok := pairs[i+1].(string)
+		if !ok {
+			continue
+		}
+		switch k := pairs[i].(type) {
+		case LocStr:
+			v.words[k[EN]] = text
+		case *LocStr:
+			v.words[k[EN]] = text
+		}
+	}
+	return numLangs + lang(id)
+}
+
+// getVariant returns the registered variant for l, or nil for base languages
+func getVariant(l lang) *langVariant {
+	if l < numLangs {
+		return nil
+	}
+	variantsMu.RLock()
+	defer variantsMu.RUnlock()
+	if int(l-numLangs) < len(variants) {
+		return variants[l-numLangs]
+	}
+	return nil
+}
+
+// baseLang returns the LocStr slot language for l (itself for base languages)
+func (l lang) baseLang() lang {
+	if l < numLangs {
+		return l
+	}
+	if v := getVariant(l); v != nil {
+		return v.base
+	}
+	return EN
+}
+
+// matchVariant returns the most specific registered variant of base that
+// matches the tag's script and region, if any
+func matchVariant(base lang, t langTag) (lang, bool) {
+	script := t.script
+	if script == "" && base == ZH {
+		// Chinese regions imply a script when none is given
+		switch t.region {
+		case "TW", "HK", "MO":
+			script = "Hant"
+		case "CN", "SG":
+			script = "Hans"
+		}
+	}
+
+	variantsMu.RLock()
+	defer variantsMu.RUnlock()
+
+	best, bestScore := -1, 0
+	for i, v := range variants {
+		if v.base != base {
+			continue
+		}
+		if (v.script != "" && v.script != script) || (v.region != "" && v.region != t.region) {
+			continue
+		}
+		score := 0
+		if v.script != "" {
+			score++
+		}
+		if v.region != "" {
+			score += 2
+		}
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	if best < 0 {
+		return base, false
+	}
+	return numLangs + lang(best), true
+}
@@ -0,0 +1,119 @@
+package fmt
+
+import "testing"
+
+// keepVariants restores the variant registry when the test ends, so the
+// variants a test registers do not change negotiation in other tests
+func keepVariants(t *testing.T) {
+	variantsMu.Lock()
+	saved := make([]*langVariant, len(variants))
+	for i, v := range variants {
+		cp := *v
+		cp.words = make(map[string]string, len(v.words))
+		for k, w := range v.words {
+			cp.words[k] = w
+		}
+		saved[i] = &cp
+	}
+	variantsMu.Unlock()
+	t.Cleanup(func() {
+		variantsMu.Lock()
+		variants = saved
+		variantsMu.Unlock()
+	})
+}
+
+func TestLangVariant(t *testing.T) {
+	keepVariants(t)
+	defer OutLang(EN)
+
+	ptPT := AddVariant("pt_PT", D.Files, "Ficheiros", &D.Email, "Correio eletrónico")
+	if got := ptPT.String(); got != "pt-PT" {
+		t.Errorf("expected tag 'pt-PT', got %q", got)
+	}
+
+	t.Run("override", func(t *testing.T) {
+		if got := Translate(ptPT, D.Files).String(); got != "Ficheiros" {
+			t.Errorf("expected 'Ficheiros', got %q", got)
+		}
+		if got := Translate(ptPT, D.Email).String(); got != "Correio eletrónico" {
+			t.Errorf("expected 'Correio eletrónico', got %q", got)
+		}
+	})
+
+	t.Run("base fallback", func(t *testing.T) {
+		if got := Translate(ptPT, D.Format, D.Invalid).String(); got != "Formato Inválido" {
+			t.Errorf("expected base PT 'Formato Inválido', got %q", got)
+		}
+	})
+
+	t.Run("OutLang selects variant", func(t *testing.T) {
+		if got := OutLang("pt-PT"); got != "pt-PT" {
+			t.Errorf("expected 'pt-PT', got %q", got)
+		}
+		if got := Translate(D.Files).String(); got != "Ficheiros" {
+			t.Errorf("expected 'Ficheiros', got %q", got)
+		}
+		if got := Err(D.Files, D.Empty).Error(); got != "Ficheiros Vazio" {
+			t.Errorf("expected 'Ficheiros Vazio', got %q", got)
+		}
+	})
+
+	t.Run("unregistered region uses base", func(t *testing.T) {
+		if got := OutLang("pt-BR"); got != "PT" {
+			t.Errorf("expected 'PT', got %q", got)
+		}
+		if got := Translate(D.Files).String(); got != "Arquivos" {
+			t.Errorf("expected 'Arquivos', got %q", got)
+		}
+	})
+
+	t.Run("merge overrides", func(t *testing.T) {
+		again := AddVariant("pt-PT", D.Field, "Campo PT")
+		if again != ptPT {
+			t.Errorf("expected same variant value, got %v and %v", again, ptPT)
+		}
+		if got := Lang("pt-PT").Translate(D.Field, D.Files).String(); got != "Campo PT Ficheiros" {
+			t.Errorf("expected 'Campo PT Ficheiros', got %q", got)
+		}
+	})
+
+	t.Run("unsupported base", func(t *testing.T) {
+		if got := AddVariant("ja-JP", D.Files, "x"); got != EN {
+			t.Errorf("expected EN for unsupported base, got %v", got)
+		}
+	})
+}
+
+func TestLangVariantNegotiation(t *testing.T) {
+	keepVariants(t)
+	hant := AddVariant("zh-Hant", D.Files, "檔案")
+	esMX := AddVariant("es-MX", D.Email, "Correo")
+
+	tests := []struct {
+		in   string
+		want lang
+	}{
+		{"zh-Hant-TW", hant},
+		{"zh-TW", hant},
+		{"zh-HK", hant},
+		{"zh-CN", ZH},
+		{"zh", ZH},
+		{"es-MX", esMX},
+		{"es-ES", ES},
+		{"de-CH, es-MX;q=0.5", DE},
+		{"ja, es-mx;q=0.9, es;q=0.8", esMX},
+	}
+	for _, tt := range tests {
+		if got := Lang(tt.in); got.l != tt.want {
+			t.Errorf("Lang(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+
+	if got := Lang("zh-TW").Translate(D.Files).String(); got != "檔案" {
+		t.Errorf("expected '檔案', got %q", got)
+	}
+	if got := Lang("zh-TW").Translate(D.Format).String(); got != "格式" {
+		t.Errorf("expected base ZH '格式', got %q", got)
+	}
+}
@@ -0,0 +1,19 @@
+//go:build !wasm
+
+package fmt
+
+import "os"
+
+// ConsoleSink writes lines to the terminal like WriterSink: Warning, Error
+// and network error types to stderr, everything else to stdout.
+// Tags are coloured by type unless colour is off (see UseColor, NO_COLOR).
+func ConsoleSink() Sink {
+	stdout, stderr := writerSink(os.Stdout, true), writerSink(os.Stderr, true)
+	return func(t MessageType, line string) {
+		if t.level() >= Msg.Warning.level() {
+			stderr(t, line)
+			return
+		}
+		stdout(t, line)
+	}
+}
@@ -0,0 +1,26 @@
+//go:build wasm
+
+package fmt
+
+import "syscall/js"
+
+// ConsoleSink writes lines to the browser console, using console.error,
+// console.warn, console.info or console.debug according to the MessageType
+// so the devtools level filters apply. Other types use console.log.
+func ConsoleSink() Sink {
+	console := js.Global().Get("console")
+	return func(t MessageType, line string) {
+		method := "log"
+		switch {
+		case t == Msg.Debug:
+			method = "debug"
+		case t == Msg.Info:
+			method = "info"
+		case t == Msg.Warning:
+			method = "warn"
+		case t.level() >= Msg.Error.level():
+			method = "error"
+		}
+		console.Call(method, "["+t.String()+"] "+line)
+	}
+}
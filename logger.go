@@ -0,0 +1,145 @@
+package fmt
+
+import (
+	"io"
+	"sync"
+)
+
+// Sink receives each log line with its MessageType. The line carries no tag
+// or newline: sinks decide how to present the type (see WriterSink, ConsoleSink).
+type Sink func(t MessageType, line string)
+
+// Logger writes messages formatted with Fmt to its sinks, tagged with their
+// MessageType. Messages below the minimum level are dropped before formatting.
+// A Logger is safe for concurrent use.
+//
+//	log := NewLogger(WriterSink(os.Stderr))
+//	log.SetLevel(Msg.Info)            // drop Debug output
+//	log.Info("listening on %s", addr) // "[Info] listening on :8080"
+//	log.Error("%L %L", D.Connection, D.Failed)
+//
+// Levels from lowest to highest: Debug, Normal, Info, Success, Warning and
+// Error. Network types (Connect, Auth, Parse, Timeout, Broadcast) rank as Error.
+type Logger struct {
+	mu    sync.Mutex
+	min   MessageType
+	sinks []Sink
+}
+
+// NewLogger returns a Logger writing to the given sinks, or to ConsoleSink
+// when none is given. The minimum level is Msg.Debug (everything is written).
+func NewLogger(sinks ...Sink) *Logger {
+	if len(sinks) == 0 {
+		sinks = []Sink{ConsoleSink()}
+	}
+	return &Logger{min: Msg.Debug, sinks: sinks}
+}
+
+// SetLevel drops messages ranking below min (see Logger for the order)
+func (l *Logger) SetLevel(min MessageType) *Logger {
+	l.mu.Lock()
+	l.min = min
+	l.mu.Unlock()
+	return l
+}
+
+// AddSink adds a destination for the following messages
+func (l *Logger) AddSink(s Sink) *Logger {
+	l.mu.Lock()
+	l.sinks = append(l.sinks, s)
+	l.mu.Unlock()
+	return l
+}
+
+// Enabled reports whether messages of type t are written
+func (l *Logger) Enabled(t MessageType) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return t.level() >= l.min.level()
+}
+
+// Log formats the message with Fmt and writes it to every sink as type t
+func (l *Logger) Log(t MessageType, format string, args ...any) {
+	if !l.Enabled(t) {
+		return
+	}
+	line := Fmt(format, args...)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, s := range l.sinks {
+		s(t, line)
+	}
+}
+
+// Debug logs a diagnostic message (Msg.Debug)
+func (l *Logger) Debug(format string, args ...any) { l.Log(Msg.Debug, format, args...) }
+
+// Info logs an informational message (Msg.Info)
+func (l *Logger) Info(format string, args ...any) { l.Log(Msg.Info, format, args...) }
+
+// Warn logs a warning (Msg.Warning)
+func (l *Logger) Warn(format string, args ...any) { l.Log(Msg.Warning, format, args...) }
+
+// Error logs an error (Msg.Error)
+func (l *Logger) Error(format string, args ...any) { l.Log(Msg.Error, format, args...) }
+
+// Success logs a success message (Msg.Success)
+func (l *Logger) Success(format string, args ...any) { l.Log(Msg.Success, format, args...) }
+
+// Connect logs a connection error (Msg.Connect)
+func (l *Logger) Connect(format string, args ...any) { l.Log(Msg.Connect, format, args...) }
+
+// Auth logs an authentication error (Msg.Auth)
+func (l *Logger) Auth(format string, args ...any) { l.Log(Msg.Auth, format, args...) }
+
+// Parse logs a parse/decode error (Msg.Parse)
+func (l *Logger) Parse(format string, args ...any) { l.Log(Msg.Parse, format, args...) }
+
+// Timeout logs a timeout error (Msg.Timeout)
+func (l *Logger) Timeout(format string, args ...any) { l.Log(Msg.Timeout, format, args...) }
+
+// Broadcast logs a broadcast/send error (Msg.Broadcast)
+func (l *Logger) Broadcast(format string, args ...any) { l.Log(Msg.Broadcast, format, args...) }
+
+// WriterSink writes each line to w as "[Type] line\n"
+func WriterSink(w io.Writer) Sink {
+	return writerSink(w, false)
+}
+
+// writerSink writes "[Type] line\n" to w, with the tag in the colour of the
+// type when color is true and styling is enabled (see UseColor)
+func writerSink(w io.Writer, color bool) Sink {
+	return func(t MessageType, line string) {
+		tag := "[" + t.String() + "]"
+		if color {
+			tag = Convert(tag).TypeColor(t).String()
+		}
+		c := GetConv()
+		c.WrString(BuffOut, tag)
+		c.wrByte(BuffOut, ' ')
+		c.WrString(BuffOut, line)
+		c.wrByte(BuffOut, '\n')
+		w.Write(c.getBytes(BuffOut))
+		c.putConv()
+	}
+}
+
+// level returns the rank of t used by Logger.SetLevel
+func (t MessageType) level() int {
+	switch t {
+	case Msg.Debug:
+		return 0
+	case Msg.Normal:
+		return 1
+	case Msg.Info:
+		return 2
+	case Msg.Success:
+		return 3
+	case Msg.Warning:
+		return 4
+	}
+	if t >= firstCustomType {
+		return 2 // Registered types rank like Info
+	}
+	return 5 // Error and network error types
+}
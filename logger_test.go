@@ -0,0 +1,98 @@
+package fmt
+
+import (
+	"sync"
+	"testing"
+)
+
+type testLogWriter struct{ data []byte }
+
+func (w *testLogWriter) Write(p []byte) (int, error) {
+	w.data = append(w.data, p...)
+	return len(p), nil
+}
+
+func TestLogger(t *testing.T) {
+	OutLang(EN)
+	defer OutLang(EN)
+
+	var types []MessageType
+	var lines []string
+	collect := func(mt MessageType, line string) {
+		types = append(types, mt)
+		lines = append(lines, line)
+	}
+
+	log := NewLogger(collect)
+	log.Debug("debug %d", 1)
+	log.Info("listening on %s", ":8080")
+	log.Warn("%L", D.Warning)
+	log.Error("%L %L", D.Connection, D.Failed)
+	log.Success("done")
+	log.Timeout("after %ds", 3)
+
+	want := []struct {
+		t    MessageType
+		line string
+	}{
+		{Msg.Debug, "debug 1"},
+		{Msg.Info, "listening on :8080"},
+		{Msg.Warning, "Warning"},
+		{Msg.Error, "Connection Failed"},
+		{Msg.Success, "done"},
+		{Msg.Timeout, "after 3s"},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d lines, got %v", len(want), lines)
+	}
+	for i, w := range want {
+		if types[i] != w.t || lines[i] != w.line {
+			t.Errorf("line %d: expected %v %q, got %v %q", i, w.t, w.line, types[i], lines[i])
+		}
+	}
+
+	t.Run("min level", func(t *testing.T) {
+		types, lines = nil, nil
+		log.SetLevel(Msg.Warning)
+		log.Debug("x")
+		log.Info("x")
+		log.Success("x")
+		log.Warn("kept")
+		log.Auth("kept")
+		if len(lines) != 2 || types[0] != Msg.Warning || types[1] != Msg.Auth {
+			t.Errorf("expected only Warning and Auth, got %v %v", types, lines)
+		}
+		if log.Enabled(Msg.Info) || !log.Enabled(Msg.Error) {
+			t.Error("unexpected Enabled result")
+		}
+	})
+
+	t.Run("WriterSink", func(t *testing.T) {
+		w := &testLogWriter{}
+		l := NewLogger(WriterSink(w))
+		l.Info("a=%d", 1)
+		l.Error("b")
+		if got := string(w.data); got != "[Info] a=1\n[Error] b\n" {
+			t.Errorf("unexpected output %q", got)
+		}
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		w := &testLogWriter{}
+		l := NewLogger(WriterSink(w))
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 50; j++ {
+					l.Info("worker %d", i)
+				}
+			}(i)
+		}
+		wg.Wait()
+		if got := Count(string(w.data), "\n"); got != 400 {
+			t.Errorf("expected 400 lines, got %d", got)
+		}
+	})
+}
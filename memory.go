@@ -81,6 +81,16 @@ func (c *Conv) resetAllBuffers() {
 	c.outLen = 0
 	c.workLen = 0
 	c.errLen = 0
+	c.resetErrRecord()
+	c.msgType, c.typed = Msg.Normal, false
+}
+
+// resetErrRecord clears the parts recorded for the error buffer
+func (c *Conv) resetErrRecord() {
+	c.errArgs = nil
+	c.errFormat = ""
+	c.errLang = EN
+	c.wrapped = nil
 }
 
 // =============================================================================
@@ -187,6 +197,7 @@ func (c *Conv) ResetBuffer(dest BuffDest) {
 	case BuffErr:
 		c.errLen = 0
 		c.err = c.err[:0]
+		c.resetErrRecord()
 		// Invalid destinations are silently ignored (no-op)
 	}
 }
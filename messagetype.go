@@ -18,7 +18,10 @@ var Msg = struct {
 	Parse     MessageType // Parse/decode error
 	Timeout   MessageType // Timeout error
 	Broadcast MessageType // Broadcast/send error
-}{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+	// Logging
+	Debug MessageType // Diagnostic output, below Normal (see Logger)
+}{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
 
 // Helper methods for MessageType
 func (t MessageType) IsNormal() bool  { return t == Msg.Normal }
@@ -26,6 +29,7 @@ func (t MessageType) IsInfo() bool    { return t == Msg.Info }
 func (t MessageType) IsError() bool   { return t == Msg.Error }
 func (t MessageType) IsWarning() bool { return t == Msg.Warning }
 func (t MessageType) IsSuccess() bool { return t == Msg.Success }
+func (t MessageType) IsDebug() bool   { return t == Msg.Debug }
 
 // Network/SSE helper methods
 func (t MessageType) IsConnect() bool   { return t == Msg.Connect }
@@ -59,12 +63,18 @@ func (t MessageType) String() string {
 		return "Timeout"
 	case Msg.Broadcast:
 		return "Broadcast"
+	case Msg.Debug:
+		return "Debug"
 	default:
+		if def, ok := getMsgType(t); ok {
+			return def.name // Registered with AddMessageType
+		}
 		return "Normal"
 	}
 }
 
-// Pre-compiled patterns for efficient buffer matching
+// English keywords for efficient buffer matching. Translations of the
+// dictionary terms for each type are added in messagetype_pattern.go
 var (
 	errorPatterns = [][]byte{
 		[]byte("error"), []byte("failed"), []byte("exit status 1"),
@@ -81,12 +91,51 @@ var (
 	}
 )
 
-// StringType returns the string from BuffOut and its detected MessageType, then auto-releases the Conv
+// Info creates a message like Translate with the explicit type Msg.Info
+// eg: Info(D.Starting, "server").StringType() returns "Starting server", Msg.Info
+func Info(msgs ...any) *Conv {
+	return GetConv().SmartArgs(BuffOut, " ", true, false, msgs...).SetType(Msg.Info)
+}
+
+// Warn creates a message like Translate with the explicit type Msg.Warning
+func Warn(msgs ...any) *Conv {
+	return GetConv().SmartArgs(BuffOut, " ", true, false, msgs...).SetType(Msg.Warning)
+}
+
+// Success creates a message like Translate with the explicit type Msg.Success
+func Success(msgs ...any) *Conv {
+	return GetConv().SmartArgs(BuffOut, " ", true, false, msgs...).SetType(Msg.Success)
+}
+
+// SetType sets the MessageType reported by StringType, skipping text detection.
+// eg: Translate(D.Connection, D.Failed).SetType(Msg.Connect)
+func (c *Conv) SetType(t MessageType) *Conv {
+	c.msgType, c.typed = t, true
+	return c
+}
+
+// Type returns the MessageType of the error: Msg.Error for errors created
+// by Err, Errf and conversions, whatever the language of the text
+func (e *Error) Type() MessageType {
+	return e.msgType
+}
+
+// StringType returns the error text and its MessageType, like Conv.StringType
+func (e *Error) StringType() (string, MessageType) {
+	return e.msg, e.msgType
+}
+
+// StringType returns the string from BuffOut and its MessageType, then auto-releases the Conv.
+// The type set by Warn, Info, Success or SetType is returned as is; otherwise
+// it is detected from the text (see detectMessageTypeFromBuffer).
 func (c *Conv) StringType() (string, MessageType) {
 	// Get string content FIRST (before detection modifies buffer)
 	out := c.GetString(BuffOut)
-	// Detect type from BuffOut content
-	msgType := c.detectMessageTypeFromBuffer(BuffOut)
+	msgType := c.msgType
+	if !c.typed {
+		// Fallback: detect type from BuffOut content
+		msgType = c.detectMessageTypeFromBuffer(BuffOut)
+	}
 	// Auto-release
 	c.putConv()
 	return out, msgType
@@ -99,17 +148,6 @@ func (c *Conv) detectMessageTypeFromBuffer(dest BuffDest) MessageType {
 	// 2. Convert to lowercase in work buffer using existing method
 	c.changeCase(true, BuffWork)
 	// 3. Direct buffer pattern matching - NO Contains() allocations
-	if c.bufferContainsPattern(BuffWork, errorPatterns) {
-		return Msg.Error
-	}
-	if c.bufferContainsPattern(BuffWork, warningPatterns) {
-		return Msg.Warning
-	}
-	if c.bufferContainsPattern(BuffWork, successPatterns) {
-		return Msg.Success
-	}
-	if c.bufferContainsPattern(BuffWork, infoPatterns) {
-		return Msg.Info
-	}
-	return Msg.Normal
+	// Patterns cover every dictionary language and AddPattern registrations
+	return c.matchPatterns(BuffWork)
 }
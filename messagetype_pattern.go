@@ -0,0 +1,201 @@
+package fmt
+
+import (
+	"sync"
+	"unicode/utf8"
+)
+
+// typePatterns holds the lowercase patterns that identify one MessageType:
+// patterns match anywhere in the text, words only as whole words (see
+// containsWord)
+type typePatterns struct {
+	t        MessageType
+	patterns [][]byte
+	words    [][]byte
+}
+
+// Detection patterns in matching order: types registered with AddPattern
+// (network types, app types) first, then Error, Warning, Success and Info.
+var (
+	detectPatterns []typePatterns
+	numSpecific    int // leading entries added by AddPattern for other types
+	patternsMu     sync.RWMutex
+	patternsOnce   sync.Once
+)
+
+// initPatterns builds the default patterns: the English keywords plus every
+// translation of the dictionary terms for each type, matched as whole words
+// so PT "erro" does not match "terror"
+func initPatterns() {
+	patternsOnce.Do(func() {
+		detectPatterns = []typePatterns{
+			{Msg.Error, errorPatterns, withTerms(errorPatterns, D.Error, D.Failed)},
+			{Msg.Warning, warningPatterns, withTerms(warningPatterns, D.Warning)},
+			{Msg.Success, successPatterns, withTerms(successPatterns, D.Success)},
+			{Msg.Info, infoPatterns, withTerms(infoPatterns, D.Information)},
+		}
+	})
+}
+
+// withTerms returns the lowercase forms of every translation of terms that
+// are not already in base
+func withTerms(base [][]byte, terms ...LocStr) [][]byte {
+	out := append([][]byte(nil), base...)
+	for _, term := range terms {
+		for _, word := range term {
+			out = addPatternForms(out, word)
+		}
+	}
+	return out[len(base):]
+}
+
+// addPatternForms appends the forms of word that detection can meet, skipping
+// duplicates: lowercased like the detected text (see detectMessageTypeFromBuffer)
+// and, for scripts that lowering leaves unchanged (e.g. Cyrillic), with the
+// first letter in lowercase too, so "Ошибка" also matches "ошибка"
+func addPatternForms(patterns [][]byte, word string) [][]byte {
+	if word == "" {
+		return patterns
+	}
+	lower := []rune(word)
+	for i, r := range lower {
+		lower[i] = toLowerRune(r)
+	}
+	forms := []string{string(lower)}
+	if r := lower[0]; r >= 0x0410 && r <= 0x042F {
+		lower[0] = r + 0x20 // Cyrillic А-Я => а-я
+		forms = append(forms, string(lower))
+	}
+	for _, f := range forms {
+		dup := false
+		for _, p := range patterns {
+			if string(p) == f {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			patterns = append(patterns, []byte(f))
+		}
+	}
+	return patterns
+}
+
+// AddPattern registers extra words that identify a MessageType in text
+// without an explicit type (see StringType). Words can be strings or LocStr
+// terms; a LocStr adds its text in every language. Matching ignores case and
+// only accepts whole words: "warn" does not match "forewarned".
+//
+//	AddPattern(Msg.Timeout, "timed out", "deadline exceeded")
+//	AddPattern(Msg.Error, "panic:", "segfault")
+//
+// Types other than Error, Warning, Success and Info are checked first, so a
+// specific type such as Msg.Timeout wins over the generic Msg.Error.
+// Msg.Normal is the result when nothing matches and cannot take patterns.
+func AddPattern(t MessageType, words ...any) {
+	if t == Msg.Normal {
+		return
+	}
+	initPatterns()
+	patternsMu.Lock()
+	defer patternsMu.Unlock()
+
+	idx := -1
+	for i, tp := range detectPatterns {
+		if tp.t == t {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		// New specific type: insert after the other specific types
+		detectPatterns = append(detectPatterns, typePatterns{})
+		copy(detectPatterns[numSpecific+1:], detectPatterns[numSpecific:])
+		detectPatterns[numSpecific] = typePatterns{t: t}
+		idx = numSpecific
+		numSpecific++
+	}
+
+	patterns := detectPatterns[idx].words
+	for _, w := range words {
+		switch v := w.(type) {
+		case string:
+			patterns = addPatternForms(patterns, v)
+		case LocStr:
+			for _, word := range v {
+				patterns = addPatternForms(patterns, word)
+			}
+		case *LocStr:
+			if v != nil {
+				for _, word := range v {
+					patterns = addPatternForms(patterns, word)
+				}
+			}
+		}
+	}
+	detectPatterns[idx].words = patterns
+}
+
+// bufferContainsWord reports whether one of words appears in dest as a whole
+// word (see containsWord)
+func (c *Conv) bufferContainsWord(dest BuffDest, words [][]byte) bool {
+	data := c.getBytes(dest)
+	for _, w := range words {
+		if containsWord(data, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsWord reports whether w appears in s without word characters
+// touching it, so "eof" matches "unexpected eof" but not "thereof". Edges in
+// scripts written without spaces (Chinese) need no boundary.
+func containsWord(s, w []byte) bool {
+	n := len(w)
+	if n == 0 {
+		return false
+	}
+	first, _ := utf8.DecodeRune(w)
+	last, _ := utf8.DecodeLastRune(w)
+	for i := 0; i+n <= len(s); i++ {
+		if s[i] != w[0] || string(s[i:i+n]) != string(w) {
+			continue
+		}
+		if before, _ := utf8.DecodeLastRune(s[:i]); i > 0 && isWordRune(first) && isWordRune(before) {
+			continue
+		}
+		if after, _ := utf8.DecodeRune(s[i+n:]); i+n < len(s) && isWordRune(last) && isWordRune(after) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// isWordRune reports whether r is part of a word in a script that separates
+// words with spaces: ASCII letters and digits, and letters below the CJK
+// blocks (Latin, Greek, Cyrillic, Arabic, Devanagari...)
+func isWordRune(r rune) bool {
+	switch {
+	case r < utf8.RuneSelf:
+		return isAlphaNum(byte(r))
+	case r == 0xA0, r >= 0x2000 && r <= 0x206F: // No-break space, punctuation
+		return false
+	}
+	return r < 0x2E80 && r != utf8.RuneError
+}
+
+// matchPatterns returns the first MessageType whose patterns or words appear
+// in the lowercased text of dest, or Msg.Normal
+func (c *Conv) matchPatterns(dest BuffDest) MessageType {
+	initPatterns()
+	patternsMu.RLock()
+	defer patternsMu.RUnlock()
+	for _, tp := range detectPatterns {
+		if c.bufferContainsPattern(dest, tp.patterns) || c.bufferContainsWord(dest, tp.words) {
+			return tp.t
+		}
+	}
+	return Msg.Normal
+}
@@ -0,0 +1,116 @@
+package fmt
+
+import "sync"
+
+// firstCustomType is the first MessageType value available for registration
+const firstCustomType = MessageType(11) // after Msg.Debug
+
+// msgTypeDef describes a MessageType registered with AddMessageType
+type msgTypeDef struct {
+	name  string
+	label LocStr
+	color Color
+}
+
+// Registered types; index i corresponds to firstCustomType + i
+var (
+	msgTypes   []msgTypeDef
+	msgTypesMu sync.RWMutex
+)
+
+// AddMessageType registers an application message type and returns its value.
+// name is returned by String and used as SSE event name (lowercase); label is
+// the translated text returned by Label; color is used by TypeColor and the
+// console logger; patterns (strings or LocStr) are added to text detection
+// like AddPattern.
+//
+//	var Validation = AddMessageType("Validation",
+//		LocStr{"Validation", "Validación", ...}, Ansi.Magenta, "invalid input")
+//
+//	Validation.String()   // "Validation"
+//	Validation.Label(ES)  // "Validación"
+//	Convert("invalid input: age").StringType() // ..., Validation
+//
+// Registering an existing name again returns the same type and adds the
+// patterns; the built-in constants in Msg never change. For the logger,
+// registered types rank like Msg.Info.
+func AddMessageType(name string, label LocStr, color Color, patterns ...any) MessageType {
+	if name == "" {
+		return Msg.Normal
+	}
+	msgTypesMu.Lock()
+	t, found := Msg.Normal, false
+	for i, def := range msgTypes {
+		if def.name == name {
+			t, found = firstCustomType+MessageType(i), true
+			break
+		}
+	}
+	if !found {
+		if int(firstCustomType)+len(msgTypes) > 255 {
+			msgTypesMu.Unlock()
+			return Msg.Normal // MessageType is a uint8: no room left
+		}
+		msgTypes = append(msgTypes, msgTypeDef{name: name, label: label, color: color})
+		t = firstCustomType + MessageType(len(msgTypes)-1)
+	}
+	msgTypesMu.Unlock()
+
+	if len(patterns) > 0 {
+		AddPattern(t, patterns...)
+	}
+	return t
+}
+
+// getMsgType returns the registered definition of t, false for built-in or unknown types
+func getMsgType(t MessageType) (msgTypeDef, bool) {
+	if t < firstCustomType {
+		return msgTypeDef{}, false
+	}
+	msgTypesMu.RLock()
+	defer msgTypesMu.RUnlock()
+	if int(t-firstCustomType) < len(msgTypes) {
+		return msgTypes[t-firstCustomType], true
+	}
+	return msgTypeDef{}, false
+}
+
+// lastMsgType returns the highest defined MessageType, built-in or registered
+func lastMsgType() MessageType {
+	msgTypesMu.RLock()
+	defer msgTypesMu.RUnlock()
+	return firstCustomType + MessageType(len(msgTypes)) - 1
+}
+
+// Label returns the translated name of the type in the current language, or
+// in the given one (same values as Lang). Built-in types use the dictionary
+// (D.Error, D.Warning, D.Success, D.Information); Normal has no label.
+//
+//	Msg.Error.Label()   // "Error"
+//	Msg.Error.Label(DE) // "Fehler"
+func (t MessageType) Label(l ...any) string {
+	var label LocStr
+	switch t {
+	case Msg.Normal:
+		return ""
+	case Msg.Error:
+		label = D.Error
+	case Msg.Warning:
+		label = D.Warning
+	case Msg.Success:
+		label = D.Success
+	case Msg.Info:
+		label = D.Information
+	default:
+		def, ok := getMsgType(t)
+		if !ok || def.label[EN] == "" {
+			return t.String()
+		}
+		label = def.label
+	}
+	scope := LangScope{l: getCurrentLang()}
+	if len(l) > 0 {
+		scope = Lang(l[0])
+	}
+	return scope.Translate(label).String()
+}
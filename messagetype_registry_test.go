@@ -0,0 +1,85 @@
+package fmt
+
+import "testing"
+
+// keepMessageTypes restores the registered message types and patterns when
+// the test ends
+func keepMessageTypes(t *testing.T) {
+	keepPatterns(t)
+	msgTypesMu.Lock()
+	saved := append([]msgTypeDef(nil), msgTypes...)
+	msgTypesMu.Unlock()
+	t.Cleanup(func() {
+		msgTypesMu.Lock()
+		msgTypes = saved
+		msgTypesMu.Unlock()
+	})
+}
+
+func TestAddMessageType(t *testing.T) {
+	keepMessageTypes(t)
+	label := LocStr{"Validation", "Validación", "", "", "", "", "Validation", "Validierung", ""}
+	validation := AddMessageType("Validation", label, Ansi.Magenta, "invalid input")
+
+	if validation <= Msg.Debug {
+		t.Fatalf("expected value after built-in types, got %d", validation)
+	}
+	if Msg.Error != 2 || Msg.Debug != 10 {
+		t.Errorf("built-in constants changed")
+	}
+	if s := validation.String(); s != "Validation" {
+		t.Errorf("String: expected Validation, got %q", s)
+	}
+	if s := validation.Label(ES); s != "Validación" {
+		t.Errorf("Label(ES): expected Validación, got %q", s)
+	}
+	if s := validation.Label(ZH); s != "Validation" {
+		t.Errorf("Label(ZH): expected English fallback, got %q", s)
+	}
+	if c := validation.Color(); c != Ansi.Magenta {
+		t.Errorf("Color: expected Magenta, got %d", c)
+	}
+	if _, mt := Convert("Invalid input: age").StringType(); mt != validation {
+		t.Errorf("expected detection as Validation, got %v", mt)
+	}
+	if ev := SSEType("validation"); ev != validation {
+		t.Errorf("SSEType: expected Validation, got %v", ev)
+	}
+
+	// Same name returns the same type and adds patterns
+	again := AddMessageType("Validation", LocStr{}, Ansi.Red, "must be positive")
+	if again != validation {
+		t.Errorf("expected same type on re-registration, got %d", again)
+	}
+	if c := again.Color(); c != Ansi.Magenta {
+		t.Errorf("re-registration must keep the color, got %d", c)
+	}
+	if _, mt := Convert("age must be positive").StringType(); mt != validation {
+		t.Errorf("expected added pattern to detect Validation, got %v", mt)
+	}
+
+	if mt := AddMessageType("", label, Ansi.Red); mt != Msg.Normal {
+		t.Errorf("empty name: expected Normal, got %v", mt)
+	}
+}
+
+func TestMessageTypeLabel(t *testing.T) {
+	tests := []struct {
+		t    MessageType
+		lang lang
+		want string
+	}{
+		{Msg.Error, EN, "Error"},
+		{Msg.Error, DE, "Fehler"},
+		{Msg.Warning, ES, Translate(ES, D.Warning).String()},
+		{Msg.Info, FR, Translate(FR, D.Information).String()},
+		{Msg.Success, PT, "Sucesso"},
+		{Msg.Timeout, ES, "Timeout"}, // No dictionary term: name
+		{Msg.Normal, EN, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.t.Label(tt.lang); got != tt.want {
+			t.Errorf("%v.Label(%v): expected %q, got %q", tt.t, tt.lang, tt.want, got)
+		}
+	}
+}
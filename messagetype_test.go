@@ -131,3 +131,151 @@ func TestSSERelatedTypes(t *testing.T) {
 		}
 	})
 }
+
+func TestExplicitMessageType(t *testing.T) {
+	tests := []struct {
+		name string
+		conv *Conv
+		want MessageType
+		text string
+	}{
+		{"Info", Info(D.Information), Msg.Info, "Information"},
+		{"Warn", Warn(ES, D.Warning), Msg.Warning, "Advertencia"},
+		// Text that detection would classify as error keeps its explicit type
+		{"Success", Success("no errors found"), Msg.Success, "no errors found"},
+		{"SetType", Translate(D.Failed).SetType(Msg.Connect), Msg.Connect, "Failed"},
+		{"scoped", Lang(ES).Warn(D.Warning), Msg.Warning, "Advertencia"},
+	}
+	for _, tt := range tests {
+		text, mt := tt.conv.StringType()
+		if mt != tt.want || text != tt.text {
+			t.Errorf("%s: expected %q %v, got %q %v", tt.name, tt.text, tt.want, text, mt)
+		}
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		// "Falló" contains no English keyword, the type comes from Err
+		text, mt := Err(ES, D.Failed).StringType()
+		if mt != Msg.Error || text != "Falló" {
+			t.Errorf("expected 'Falló' Msg.Error, got %q %v", text, mt)
+		}
+		_, conv := Convert("x").Int()
+		if conv.(*Error).Type() != Msg.Error {
+			t.Error("expected conversion errors to be Msg.Error")
+		}
+	})
+
+	t.Run("pooled Conv does not keep the type", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			_, _ = Warn("x").StringType()
+		}
+		if _, mt := Convert("plain text").StringType(); mt != Msg.Normal {
+			t.Errorf("expected detection after reuse, got %v", mt)
+		}
+	})
+}
+
+func TestMultilingualDetection(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want MessageType
+	}{
+		{"ES failed", Translate(ES, D.Connection, D.Failed).String(), Msg.Error},
+		{"ZH error", Translate(ZH, D.Error).String(), Msg.Error},
+		{"RU failed", Translate(RU, D.Failed).String(), Msg.Error},
+		{"RU error lowercase", "произошла ошибка", Msg.Error},
+		{"DE warning", Translate(DE, D.Warning).String(), Msg.Warning},
+		{"FR success", Translate(FR, D.Success).String(), Msg.Success},
+		{"AR information", Translate(AR, D.Information).String(), Msg.Info},
+		{"PT error", "Erro ao salvar", Msg.Error},
+		{"PT error inside word", "Ferro fundido", Msg.Normal},
+		{"PT warning", "Aviso: disco cheio", Msg.Warning},
+		{"ZH error in sentence", "保存" + Translate(ZH, D.Error).String() + "了", Msg.Error},
+		{"plain", "Hola mundo", Msg.Normal},
+	}
+	for _, tt := range tests {
+		if _, mt := Convert(tt.text).StringType(); mt != tt.want {
+			t.Errorf("%s: %q expected %v, got %v", tt.name, tt.text, tt.want, mt)
+		}
+	}
+}
+
+func TestContainsWord(t *testing.T) {
+	tests := []struct {
+		text, word string
+		want       bool
+	}{
+		{"eof", "eof", true},
+		{"unexpected eof", "eof", true},
+		{"eof: read", "eof", true},
+		{"thereof", "eof", false},
+		{"eofs", "eof", false},
+		{"the eof2", "eof", false},
+		{"ferro e erro", "erro", true},
+		{"произошла ошибка.", "ошибка", true},
+		{"ошибкаx", "ошибка", false},
+		{"保存错误了", "错误", true},
+		{"panic: nil map", "panic:", true},
+		{"", "eof", false},
+		{"eof", "", false},
+	}
+	for _, tt := range tests {
+		if got := containsWord([]byte(tt.text), []byte(tt.word)); got != tt.want {
+			t.Errorf("containsWord(%q, %q): expected %v, got %v", tt.text, tt.word, tt.want, got)
+		}
+	}
+}
+
+// keepPatterns restores the detection patterns when the test ends, so words
+// a test registers do not change detection in other tests
+func keepPatterns(t *testing.T) {
+	initPatterns()
+	patternsMu.Lock()
+	saved := make([]typePatterns, len(detectPatterns))
+	for i, tp := range detectPatterns {
+		saved[i] = typePatterns{
+			t:        tp.t,
+			patterns: append([][]byte(nil), tp.patterns...),
+			words:    append([][]byte(nil), tp.words...),
+		}
+	}
+	savedSpecific := numSpecific
+	patternsMu.Unlock()
+	t.Cleanup(func() {
+		patternsMu.Lock()
+		detectPatterns, numSpecific = saved, savedSpecific
+		patternsMu.Unlock()
+	})
+}
+
+func TestAddPattern(t *testing.T) {
+	keepPatterns(t)
+	if _, mt := Convert("request timed out").StringType(); mt != Msg.Normal {
+		t.Fatalf("expected Normal before registration, got %v", mt)
+	}
+	AddPattern(Msg.Timeout, "timed out")
+	if _, mt := Convert("Request TIMED OUT").StringType(); mt != Msg.Timeout {
+		t.Errorf("expected Timeout, got %v", mt)
+	}
+	if _, mt := Convert("untimed outline").StringType(); mt != Msg.Normal {
+		t.Errorf("expected registered words to match whole words only, got %v", mt)
+	}
+	// Specific types are checked before the generic Error patterns
+	if _, mt := Convert("error: timed out").StringType(); mt != Msg.Timeout {
+		t.Errorf("expected Timeout to win over Error, got %v", mt)
+	}
+
+	AddPattern(Msg.Warning, LocStr{"Deprecated", "Obsoleto", "", "", "", "", "", "Veraltet", ""})
+	if _, mt := Convert("API obsoleto").StringType(); mt != Msg.Warning {
+		t.Errorf("expected Warning from registered LocStr, got %v", mt)
+	}
+	if _, mt := Convert("Veraltet").StringType(); mt != Msg.Warning {
+		t.Errorf("expected Warning for German form, got %v", mt)
+	}
+
+	AddPattern(Msg.Normal, "ignored")
+	if _, mt := Convert("ignored").StringType(); mt != Msg.Normal {
+		t.Errorf("expected Normal, got %v", mt)
+	}
+}
@@ -0,0 +1,535 @@
+package fmt
+
+import "unsafe"
+
+// =============================================================================
+// EXACT FLOAT CONVERSION - multiprecision decimal used where results must
+// round-trip (JSON numbers), following the algorithms of strconv
+// =============================================================================
+
+// decimal is a decimal number with up to 800 significant digits:
+// 0.d[0]d[1]...d[nd-1] × 10^dp
+type decimal struct {
+	d     [800]byte // ASCII digits, most significant first
+	nd    int       // digits used
+	dp    int       // decimal point position
+	neg   bool
+	trunc bool // nonzero digits were dropped past d[:nd]
+}
+
+// floatFormat describes the IEEE 754 layout of float32 and float64
+type floatFormat struct {
+	mantBits uint
+	expBits  uint
+	bias     int
+}
+
+var (
+	float32Format = floatFormat{23, 8, -127}
+	float64Format = floatFormat{52, 11, -1023}
+)
+
+// float64Pow10 holds the powers of ten that a float64 represents exactly
+var float64Pow10 = [...]float64{
+	1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10, 1e11,
+	1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19, 1e20, 1e21, 1e22,
+}
+
+// parseFloatExact parses a decimal literal ([-]digits[.digits][e[+-]digits],
+// already validated) to the nearest float of the given bit size (32 or 64).
+// ok is false when the value is beyond the range of that size.
+func parseFloatExact(s string, bits int) (f float64, ok bool) {
+	var d decimal
+	if !d.set(s) {
+		return 0, false
+	}
+
+	// Fast path: up to 15 digits and a power of ten that is exact in float64
+	// give a correctly rounded float64 with one multiplication or division
+	if bits == 64 && !d.trunc && d.nd <= 15 {
+		var mant uint64
+		for i := 0; i < d.nd; i++ {
+			mant = mant*10 + uint64(d.d[i]-'0')
+		}
+		exp := d.dp - d.nd
+		if exp >= -22 && exp <= 22 {
+			f = float64(mant)
+			if exp < 0 {
+				f /= float64Pow10[-exp]
+			} else {
+				f *= float64Pow10[exp]
+			}
+			if d.neg {
+				f = -f
+			}
+			return f, true
+		}
+	}
+
+	if bits == 32 {
+		b, overflow := d.floatBits(&float32Format)
+		b32 := uint32(b)
+		return float64(*(*float32)(unsafe.Pointer(&b32))), !overflow
+	}
+	b, overflow := d.floatBits(&float64Format)
+	return *(*float64)(unsafe.Pointer(&b)), !overflow
+}
+
+// set reads a decimal literal and reports whether it is well formed
+func (a *decimal) set(s string) bool {
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		a.neg = s[i] == '-'
+		i++
+	}
+	sawDot, sawDigits := false, false
+	for ; i < len(s); i++ {
+		switch b := s[i]; {
+		case b == '.':
+			if sawDot {
+				return false
+			}
+			sawDot = true
+			a.dp = a.nd
+		case b >= '0' && b <= '9':
+			sawDigits = true
+			if b == '0' && a.nd == 0 { // Leading zeros
+				a.dp--
+				continue
+			}
+			if a.nd < len(a.d) {
+				a.d[a.nd] = b
+				a.nd++
+			} else if b != '0' {
+				a.trunc = true
+			}
+		default:
+			goto exponent
+		}
+	}
+exponent:
+	if !sawDigits {
+		return false
+	}
+	if !sawDot {
+		a.dp = a.nd
+	}
+	if i < len(s) {
+		if s[i] != 'e' && s[i] != 'E' {
+			return false
+		}
+		i++
+		sign := 1
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			if s[i] == '-' {
+				sign = -1
+			}
+			i++
+		}
+		if i >= len(s) {
+			return false
+		}
+		e := 0
+		for ; i < len(s); i++ {
+			if s[i] < '0' || s[i] > '9' {
+				return false
+			}
+			if e < 10000 { // Far beyond the float range either way
+				e = e*10 + int(s[i]-'0')
+			}
+		}
+		a.dp += e * sign
+	}
+	if a.nd == 0 {
+		a.dp = 0
+	}
+	return true
+}
+
+// assign sets a to the integer v
+func (a *decimal) assign(v uint64) {
+	var buf [20]byte
+	n := 0
+	for v > 0 {
+		buf[n] = byte(v%10) + '0'
+		v /= 10
+		n++
+	}
+	a.nd = 0
+	for n--; n >= 0; n-- {
+		a.d[a.nd] = buf[n]
+		a.nd++
+	}
+	a.dp = a.nd
+	a.trim()
+}
+
+// maxDecimalShift is the largest shift done at once: digits<<59 plus the
+// carry fits in a uint64
+const maxDecimalShift = 59
+
+// shift multiplies a by 2^k (k > 0) or divides it by 2^-k (k < 0)
+func (a *decimal) shift(k int) {
+	if a.nd == 0 {
+		return
+	}
+	for ; k > maxDecimalShift; k -= maxDecimalShift {
+		a.leftShift(maxDecimalShift)
+	}
+	if k > 0 {
+		a.leftShift(uint(k))
+	}
+	for ; k < -maxDecimalShift; k += maxDecimalShift {
+		a.rightShift(maxDecimalShift)
+	}
+	if k < 0 {
+		a.rightShift(uint(-k))
+	}
+}
+
+// leftShift multiplies a by 2^k, k <= maxDecimalShift
+func (a *decimal) leftShift(k uint) {
+	// 2^59 < 10^18: the result has at most 18 more digits. Digits are written
+	// right to left 18 places after the ones being read.
+	const grow = 18
+	if a.nd > len(a.d)-grow {
+		for _, b := range a.d[len(a.d)-grow : a.nd] {
+			if b != '0' {
+				a.trunc = true
+			}
+		}
+		a.nd = len(a.d) - grow
+	}
+	w := a.nd + grow
+	var n uint64
+	for r := a.nd - 1; r >= 0; r-- {
+		n += uint64(a.d[r]-'0') << k
+		w--
+		a.d[w] = byte(n%10) + '0'
+		n /= 10
+	}
+	for n > 0 {
+		w--
+		a.d[w] = byte(n%10) + '0'
+		n /= 10
+	}
+	nd := a.nd + grow - w
+	copy(a.d[:], a.d[w:w+nd])
+	a.dp += nd - a.nd
+	a.nd = nd
+	a.trim()
+}
+
+// rightShift divides a by 2^k, k <= maxDecimalShift
+func (a *decimal) rightShift(k uint) {
+	r, w := 0, 0
+	var n uint64
+	// Read enough leading digits to get a nonzero first digit
+	for ; n>>k == 0; r++ {
+		if r >= a.nd {
+			if n == 0 {
+				a.nd = 0
+				return
+			}
+			for n>>k == 0 {
+				n *= 10
+				r++
+			}
+			break
+		}
+		n = n*10 + uint64(a.d[r]-'0')
+	}
+	a.dp -= r - 1
+
+	mask := uint64(1)<<k - 1
+	for ; r < a.nd; r++ {
+		c := uint64(a.d[r] - '0')
+		a.d[w] = byte(n>>k) + '0'
+		w++
+		n = (n&mask)*10 + c
+	}
+	for n > 0 {
+		dig := n >> k
+		n &= mask
+		if w < len(a.d) {
+			a.d[w] = byte(dig) + '0'
+			w++
+		} else if dig > 0 {
+			a.trunc = true
+		}
+		n *= 10
+	}
+	a.nd = w
+	a.trim()
+}
+
+// trim drops trailing zeros
+func (a *decimal) trim() {
+	for a.nd > 0 && a.d[a.nd-1] == '0' {
+		a.nd--
+	}
+	if a.nd == 0 {
+		a.dp = 0
+	}
+}
+
+// shouldRoundUp reports whether rounding to nd digits goes up; exact halves
+// round to even
+func (a *decimal) shouldRoundUp(nd int) bool {
+	if nd < 0 || nd >= a.nd {
+		return false
+	}
+	if a.d[nd] == '5' && nd+1 == a.nd {
+		if a.trunc {
+			return true
+		}
+		return nd > 0 && (a.d[nd-1]-'0')%2 == 1
+	}
+	return a.d[nd] >= '5'
+}
+
+// roundedInteger returns the integer part of a, rounded to nearest even
+func (a *decimal) roundedInteger() uint64 {
+	if a.dp > 20 {
+		return 0xFFFFFFFFFFFFFFFF
+	}
+	var n uint64
+	i := 0
+	for ; i < a.dp && i < a.nd; i++ {
+		n = n*10 + uint64(a.d[i]-'0')
+	}
+	for ; i < a.dp; i++ {
+		n *= 10
+	}
+	if a.shouldRoundUp(a.dp) {
+		n++
+	}
+	return n
+}
+
+// floatBitsStep holds the binary shifts that keep a in a 1-9 digit range
+// per decimal digit of the point
+var floatBitsStep = [...]int{1, 3, 6, 9, 13, 16, 19, 23, 26}
+
+// floatBits returns the bits of the float of format f nearest to a
+func (a *decimal) floatBits(f *floatFormat) (bits uint64, overflow bool) {
+	var exp int
+	var mant uint64
+	switch {
+	case a.nd == 0 || a.dp < -330:
+		exp = f.bias // Zero, or underflow to zero
+	case a.dp > 310:
+		overflow = true
+	default:
+		// Scale by powers of two until a is in [0.5, 1)
+		for a.dp > 0 {
+			n := 27
+			if a.dp < len(floatBitsStep) {
+				n = floatBitsStep[a.dp]
+			}
+			a.shift(-n)
+			exp += n
+		}
+		for a.dp < 0 || (a.dp == 0 && a.d[0] < '5') {
+			n := 27
+			if -a.dp < len(floatBitsStep) {
+				n = floatBitsStep[-a.dp]
+			}
+			a.shift(n)
+			exp -= n
+		}
+		exp-- // [1, 2)
+
+		// Denormals have the smallest exponent and fewer mantissa bits
+		if exp < f.bias+1 {
+			n := f.bias + 1 - exp
+			a.shift(-n)
+			exp += n
+		}
+		if exp-f.bias >= 1<<f.expBits-1 {
+			overflow = true
+			break
+		}
+		a.shift(int(1 + f.mantBits))
+		mant = a.roundedInteger()
+		if mant == 2<<f.mantBits { // Rounding carried into a new bit
+			mant >>= 1
+			exp++
+			if exp-f.bias >= 1<<f.expBits-1 {
+				overflow = true
+				break
+			}
+		}
+		if mant&(1<<f.mantBits) == 0 {
+			exp = f.bias // Denormal
+		}
+	}
+	if overflow {
+		mant, exp = 0, 1<<f.expBits-1+f.bias // ±Inf
+	}
+	bits = mant & (uint64(1)<<f.mantBits - 1)
+	bits |= uint64((exp-f.bias)&(1<<f.expBits-1)) << f.mantBits
+	if a.neg {
+		bits |= 1 << f.mantBits << f.expBits
+	}
+	return bits, overflow
+}
+
+// setShortest sets a to the shortest decimal that parses back to f at the
+// given bit size (32 or 64), like strconv with precision -1. The sign of f
+// is ignored.
+func (a *decimal) setShortest(f float64, bits int) {
+	fm := &float64Format
+	b := *(*uint64)(unsafe.Pointer(&f))
+	if bits == 32 {
+		f32 := float32(f)
+		fm, b = &float32Format, uint64(*(*uint32)(unsafe.Pointer(&f32)))
+	}
+	exp := int(b>>fm.mantBits) & (1<<fm.expBits - 1)
+	mant := b & (uint64(1)<<fm.mantBits - 1)
+	if exp == 0 {
+		exp++ // Denormal
+	} else {
+		mant |= uint64(1) << fm.mantBits
+	}
+	exp += fm.bias
+
+	*a = decimal{}
+	a.assign(mant)
+	a.shift(exp - int(fm.mantBits))
+	if mant == 0 {
+		return
+	}
+	// Enough digits already when the decimal is shorter than the float
+	// precision (log2(10) ~ 3.32)
+	if exp > fm.bias+1 && 332*(a.dp-a.nd) >= 100*(exp-int(fm.mantBits)) {
+		return
+	}
+
+	// Any decimal strictly between the halfway points to the neighbouring
+	// floats (both included when mant is even) parses back to f
+	var upper, lower decimal
+	upper.assign(mant*2 + 1)
+	upper.shift(exp - int(fm.mantBits) - 1)
+	mantLo, expLo := mant-1, exp
+	if mant <= 1<<fm.mantBits && exp != fm.bias+1 {
+		mantLo, expLo = mant*2-1, exp-1 // The float below has a smaller exponent
+	}
+	lower.assign(mantLo*2 + 1)
+	lower.shift(expLo - int(fm.mantBits) - 1)
+	inclusive := mant%2 == 0
+
+	// Walk the digits until rounding up or down stays within the bounds
+	var upperDelta uint8 // 0: digits equal so far, 1: upper is one unit above, 2: more
+	for ui := 0; ; ui++ {
+		mi := ui - upper.dp + a.dp
+		if mi >= a.nd {
+			break
+		}
+		li := ui - upper.dp + lower.dp
+		l, m, u := byte('0'), byte('0'), byte('0')
+		if li >= 0 && li < lower.nd {
+			l = lower.d[li]
+		}
+		if mi >= 0 {
+			m = a.d[mi]
+		}
+		if ui < upper.nd {
+			u = upper.d[ui]
+		}
+		okDown := l != m || (inclusive && li+1 == lower.nd)
+		switch {
+		case upperDelta == 0 && m+1 < u:
+			upperDelta = 2
+		case upperDelta == 0 && m != u:
+			upperDelta = 1
+		case upperDelta == 1 && (m != '9' || u != '0'):
+			upperDelta = 2
+		}
+		okUp := upperDelta > 0 && (inclusive || upperDelta > 1 || ui+1 < upper.nd)
+		switch {
+		case okDown && okUp:
+			if a.shouldRoundUp(mi + 1) {
+				a.roundUp(mi + 1)
+			} else {
+				a.roundDown(mi + 1)
+			}
+			return
+		case okDown:
+			a.roundDown(mi + 1)
+			return
+		case okUp:
+			a.roundUp(mi + 1)
+			return
+		}
+	}
+}
+
+// roundDown truncates a to nd digits
+func (a *decimal) roundDown(nd int) {
+	a.nd = nd
+	a.trim()
+}
+
+// roundUp rounds a up to nd digits
+func (a *decimal) roundUp(nd int) {
+	for i := nd - 1; i >= 0; i-- {
+		if a.d[i] < '9' {
+			a.d[i]++
+			a.nd = i + 1
+			return
+		}
+	}
+	a.d[0] = '1' // 999 -> 1000
+	a.nd = 1
+	a.dp++
+}
+
+// wrDecimal writes a in plain form (123.45, 0.001) or in exponent form
+// (1.2345e+2, 1e-3)
+func (c *Conv) wrDecimal(dest BuffDest, a *decimal, exponent bool) {
+	if a.nd == 0 {
+		c.wrByte(dest, '0')
+		return
+	}
+	if exponent {
+		c.wrByte(dest, a.d[0])
+		if a.nd > 1 {
+			c.wrByte(dest, '.')
+			c.wrBytes(dest, a.d[1:a.nd])
+		}
+		c.wrByte(dest, 'e')
+		exp := a.dp - 1
+		if exp < 0 {
+			c.wrByte(dest, '-')
+			exp = -exp
+		} else {
+			c.wrByte(dest, '+')
+		}
+		c.wrIntBase(dest, int64(exp), 10, false)
+		return
+	}
+
+	// Integer part, padded with zeros up to the point
+	if a.dp <= 0 {
+		c.wrByte(dest, '0')
+	} else {
+		for i := 0; i < a.dp; i++ {
+			if i < a.nd {
+				c.wrByte(dest, a.d[i])
+			} else {
+				c.wrByte(dest, '0')
+			}
+		}
+	}
+	if a.dp < a.nd {
+		c.wrByte(dest, '.')
+		for i := a.dp; i < a.nd; i++ {
+			if i < 0 {
+				c.wrByte(dest, '0')
+			} else {
+				c.wrByte(dest, a.d[i])
+			}
+		}
+	}
+}
@@ -11,7 +11,7 @@ import "reflect"
 func (c *Conv) Float64() (float64, error) {
 	val := c.parseFloatBase()
 	if c.hasContent(BuffErr) {
-		return 0, c
+		return 0, c.newError()
 	}
 	return val, nil
 }
@@ -45,10 +45,10 @@ func (c *Conv) toFloat64Reflect(arg any) (float64, bool) {
 func (c *Conv) Float32() (float32, error) {
 	val := c.parseFloatBase()
 	if c.hasContent(BuffErr) {
-		return 0, c
+		return 0, c.newError()
 	}
 	if val > 3.4028235e+38 {
-		return 0, c.wrErr(D.Number, D.Overflow)
+		return 0, c.wrErrKind(ErrRange, D.Number, D.Overflow).newError()
 	}
 	return float32(val), nil
 }
@@ -60,7 +60,7 @@ func (c *Conv) parseFloatBase() float64 {
 
 	s := c.GetString(BuffOut)
 	if len(s) == 0 {
-		c.wrErr(D.String, D.Empty)
+		c.wrErrKind(ErrSyntax, D.String, D.Empty)
 		return 0
 	}
 
@@ -76,13 +76,13 @@ func (c *Conv) parseFloatBase() float64 {
 		negative = true
 		i = 1
 		if len(s) == 1 {
-			c.wrErr(D.Format, D.Invalid)
+			c.wrErrKind(ErrSyntax, D.Format, D.Invalid)
 			return 0
 		}
 	case '+':
 		i = 1
 		if len(s) == 1 {
-			c.wrErr(D.Format, D.Invalid)
+			c.wrErrKind(ErrSyntax, D.Format, D.Invalid)
 			return 0
 		}
 	}
@@ -90,7 +90,7 @@ func (c *Conv) parseFloatBase() float64 {
 	// Parse integer part
 	for ; i < len(s) && s[i] != '.'; i++ {
 		if s[i] < '0' || s[i] > '9' {
-			c.wrErr(D.Character, D.Invalid)
+			c.wrErrKind(ErrSyntax, D.Character, D.Invalid)
 			return 0
 		}
 		result = result*10 + float64(s[i]-'0')
@@ -102,7 +102,7 @@ func (c *Conv) parseFloatBase() float64 {
 		i++ // Skip decimal point
 		for ; i < len(s); i++ {
 			if s[i] < '0' || s[i] > '9' {
-				c.wrErr(D.Character, D.Invalid)
+				c.wrErrKind(ErrSyntax, D.Character, D.Invalid)
 				return 0
 			}
 			decimalPlaces++
@@ -7,22 +7,16 @@ func (c *Conv) parseIntString(s string, base int, signed bool) int64 {
 	for i := 0; i < len(s); i++ {
 		if s[i] == '.' {
 			// Try to parse as float, then truncate
-			// Use BuffWork to avoid conflicts with BuffOut
-			c.ResetBuffer(BuffWork)
-			c.WrString(BuffWork, s)
-
-			// Swap buffers temporarily to use parseFloatBase
-			c.swapBuff(BuffOut, BuffErr)  // Save current BuffOut to BuffErr
-			c.swapBuff(BuffWork, BuffOut) // Move string to BuffOut for parsing
+			// Keep the original BuffOut in BuffWork while parseFloatBase reads s;
+			// BuffErr is left untouched so parse errors reach the caller
+			c.swapBuff(BuffOut, BuffWork)
+			c.WrString(BuffOut, s)
 
 			f := c.parseFloatBase()
-			hasError := c.hasContent(BuffErr)
 
-			// Restore original BuffOut
-			c.swapBuff(BuffOut, BuffWork) // Move parsed content to BuffWork
-			c.swapBuff(BuffErr, BuffOut)  // Restore original BuffOut
+			c.swapBuff(BuffWork, BuffOut) // Restore original BuffOut
 
-			if hasError {
+			if c.hasContent(BuffErr) {
 				return 0
 			}
 			return int64(f)
@@ -36,19 +30,19 @@ func (c *Conv) parseIntString(s string, base int, signed bool) int64 {
 	i := 0
 	if len(s) > 0 && s[0] == '-' {
 		if !signed {
-			c.wrErr(D.Number, D.Negative, D.Not, D.Allowed)
+			c.wrErrKind(ErrRange, D.Number, D.Negative, D.Not, D.Allowed)
 			return 0
 		}
 		neg = true
 		i = 1
 		if len(s) == 1 {
-			c.wrErr(D.Format, D.Invalid)
+			c.wrErrKind(ErrSyntax, D.Format, D.Invalid)
 			return 0
 		}
 	} else if len(s) > 0 && s[0] == '+' {
 		i = 1
 		if len(s) == 1 {
-			c.wrErr(D.Format, D.Invalid)
+			c.wrErrKind(ErrSyntax, D.Format, D.Invalid)
 			return 0
 		}
 	}
@@ -64,11 +58,11 @@ func (c *Conv) parseIntString(s string, base int, signed bool) int64 {
 		case 'A' <= ch && ch <= 'Z':
 			v = ch - 'A' + 10
 		default:
-			c.wrErr(D.Format, D.Invalid)
+			c.wrErrKind(ErrSyntax, D.Format, D.Invalid)
 			return 0
 		}
 		if int(v) >= base {
-			c.wrErr(D.Format, D.Invalid)
+			c.wrErrKind(ErrSyntax, D.Format, D.Invalid)
 			return 0
 		}
 		n = n*int64(base) + int64(v)
@@ -85,10 +79,10 @@ func (c *Conv) parseIntString(s string, base int, signed bool) int64 {
 func (c *Conv) Int(base ...int) (int, error) {
 	val := c.parseIntBase(base...)
 	if val < -2147483648 || val > 2147483647 {
-		return 0, c.wrErr(D.Number, D.Overflow)
+		return 0, c.wrErrKind(ErrRange, D.Number, D.Overflow).newError()
 	}
 	if c.hasContent(BuffErr) {
-		return 0, c
+		return 0, c.newError()
 	}
 	return int(val), nil
 }
@@ -98,10 +92,10 @@ func (c *Conv) Int(base ...int) (int, error) {
 func (c *Conv) Int32(base ...int) (int32, error) {
 	val := c.parseIntBase(base...)
 	if val < -2147483648 || val > 2147483647 {
-		return 0, c.wrErr(D.Number, D.Overflow)
+		return 0, c.wrErrKind(ErrRange, D.Number, D.Overflow).newError()
 	}
 	if c.hasContent(BuffErr) {
-		return 0, c
+		return 0, c.newError()
 	}
 	return int32(val), nil
 }
@@ -111,7 +105,7 @@ func (c *Conv) Int32(base ...int) (int32, error) {
 func (c *Conv) Int64(base ...int) (int64, error) {
 	val := c.parseIntBase(base...)
 	if c.hasContent(BuffErr) {
-		return 0, c
+		return 0, c.newError()
 	}
 	return val, nil
 }
@@ -169,9 +163,9 @@ func (c *Conv) wrIntBase(dest BuffDest, val int64, base int, signed bool, upper
 		return
 	}
 	negative := signed && val < 0
-	uval := val
+	uval := uint64(val) // Unsigned callers pass uint64 values converted to int64
 	if negative {
-		uval = -val
+		uval = uint64(-val)
 	}
 	useUpper := false
 	if len(upper) > 0 && upper[0] {
@@ -187,8 +181,8 @@ func (c *Conv) wrIntBase(dest BuffDest, val int64, base int, signed bool, upper
 	idx := len(out)
 	for uval > 0 {
 		idx--
-		out[idx] = digits[uval%int64(base)]
-		uval /= int64(base)
+		out[idx] = digits[uval%uint64(base)]
+		uval /= uint64(base)
 	}
 	if negative {
 		idx--
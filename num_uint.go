@@ -59,10 +59,10 @@ func (c *Conv) toUint64(arg any) (uint64, bool) {
 func (c *Conv) Uint(base ...int) (uint, error) {
 	val := c.parseIntBase(base...)
 	if val < 0 || val > 4294967295 {
-		return 0, c.wrErr(D.Number, D.Overflow)
+		return 0, c.wrErrKind(ErrRange, D.Number, D.Overflow).newError()
 	}
 	if c.hasContent(BuffErr) {
-		return 0, c
+		return 0, c.newError()
 	}
 	return uint(val), nil
 }
@@ -71,10 +71,10 @@ func (c *Conv) Uint(base ...int) (uint, error) {
 func (c *Conv) Uint32(base ...int) (uint32, error) {
 	val := c.parseIntBase(base...)
 	if val < 0 || val > 4294967295 {
-		return 0, c.wrErr(D.Number, D.Overflow)
+		return 0, c.wrErrKind(ErrRange, D.Number, D.Overflow).newError()
 	}
 	if c.hasContent(BuffErr) {
-		return 0, c
+		return 0, c.newError()
 	}
 	return uint32(val), nil
 }
@@ -83,7 +83,7 @@ func (c *Conv) Uint32(base ...int) (uint32, error) {
 func (c *Conv) Uint64(base ...int) (uint64, error) {
 	val := c.parseIntBase(base...)
 	if c.hasContent(BuffErr) {
-		return 0, c
+		return 0, c.newError()
 	}
 	return uint64(val), nil
 }
@@ -14,7 +14,7 @@ func (c *Conv) ExtractValue(delimiters ...string) (string, error) {
 	}
 	_, after, found := c.splitByDelimiterWithBuffer(src, d)
 	if !found {
-		return "", c.wrErr(D.Format, D.Invalid, D.Delimiter, D.Not, D.Found)
+		return "", c.wrErrKind(ErrNotFound, D.Format, D.Invalid, D.Delimiter, D.Not, D.Found).newError()
 	}
 	return after, nil
 }
@@ -1,8 +1,81 @@
 package fmt
 
-// Quote wraps a string in double quotes and escapes any special characters
+import "unicode/utf8"
+
+// Quote wraps a string in double quotes and escapes it like strconv.Quote:
+// \", \\, \a \b \f \n \r \t \v, other control characters as \x00, non
+// printable runes as \u2028 or \U000e0001 and invalid UTF-8 bytes as \xff.
 // Example: Quote("hello \"world\"") returns "\"hello \\\"world\\\"\""
 func (c *Conv) Quote() *Conv {
+	return c.quoteOut('"', false)
+}
+
+// QuoteToASCII is like Quote but also escapes every non-ASCII rune,
+// like strconv.QuoteToASCII.
+// Example: Convert("año ☺").QuoteToASCII().String() returns `"a\u00f1o \u263a"`
+func (c *Conv) QuoteToASCII() *Conv {
+	return c.quoteOut('"', true)
+}
+
+// Backquote wraps the string in backquotes when it can be a raw string
+// literal (see CanBackquote), and quotes it like Quote otherwise.
+// Example: Convert(`C:\path`).Backquote().String() returns "`C:\\path`"
+func (c *Conv) Backquote() *Conv {
+	if c.hasContent(BuffErr) {
+		return c // Error chain interruption
+	}
+	if !CanBackquote(c.GetString(BuffOut)) {
+		return c.Quote()
+	}
+	c.ResetBuffer(BuffWork)
+	c.wrByte(BuffWork, '`')
+	c.wrBytes(BuffWork, c.out[:c.outLen])
+	c.wrByte(BuffWork, '`')
+	c.swapBuff(BuffWork, BuffOut)
+	return c
+}
+
+// CanBackquote reports whether s can be written as a raw string literal:
+// valid UTF-8 without backquotes, byte order marks or control characters
+// other than tab, like strconv.CanBackquote.
+func CanBackquote(s string) bool {
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if (r == utf8.RuneError && size == 1) || r == '`' || r == '\uFEFF' ||
+			(r < ' ' && r != '\t') || r == 0x7F {
+			return false
+		}
+		i += size
+	}
+	return true
+}
+
+// QuoteRune returns r as a single-quoted Go character literal,
+// like strconv.QuoteRune. Example: QuoteRune('☺') returns `'☺'`
+func QuoteRune(r rune) string {
+	return quoteRune(r, false)
+}
+
+// QuoteRuneToASCII is like QuoteRune but escapes non-ASCII runes.
+// Example: QuoteRuneToASCII('☺') returns `'\u263a'`
+func QuoteRuneToASCII(r rune) string {
+	return quoteRune(r, true)
+}
+
+// quoteRune quotes r; invalid runes are written as U+FFFD like strconv
+func quoteRune(r rune, ascii bool) string {
+	if !utf8.ValidRune(r) {
+		r = utf8.RuneError
+	}
+	c := GetConv()
+	c.wrByte(BuffOut, '\'')
+	c.wrQuotedRune(BuffOut, r, '\'', ascii)
+	c.wrByte(BuffOut, '\'')
+	return c.String()
+}
+
+// quoteOut replaces the output with its quoted form
+func (c *Conv) quoteOut(quote byte, ascii bool) *Conv {
 	if c.hasContent(BuffErr) {
 		return c // Error chain interruption
 	}
@@ -14,33 +87,287 @@ func (c *Conv) Quote() *Conv {
 
 	// Use work buffer to build quoted string, then swap to output
 	c.ResetBuffer(BuffWork)
-	c.wrByte(BuffWork, '"')
-
-	// Process buffer directly without string allocation (like capitalizeASCIIOptimized)
-	for i := 0; i < c.outLen; i++ {
-		char := c.out[i]
-		switch char {
-		case '"':
-			c.wrByte(BuffWork, '\\')
-			c.wrByte(BuffWork, '"')
-		case '\\':
-			c.wrByte(BuffWork, '\\')
-			c.wrByte(BuffWork, '\\')
-		case '\n':
-			c.wrByte(BuffWork, '\\')
-			c.wrByte(BuffWork, 'n')
-		case '\r':
-			c.wrByte(BuffWork, '\\')
-			c.wrByte(BuffWork, 'r')
-		case '\t':
-			c.wrByte(BuffWork, '\\')
-			c.wrByte(BuffWork, 't')
+	c.wrQuoted(BuffWork, c.out[:c.outLen], quote, ascii)
+	c.swapBuff(BuffWork, BuffOut)
+	return c
+}
+
+// wrQuoted writes s between quote characters, escaped for a Go literal
+func (c *Conv) wrQuoted(dest BuffDest, s []byte, quote byte, ascii bool) {
+	c.wrByte(dest, quote)
+	for i := 0; i < len(s); {
+		b := s[i]
+		if b < utf8.RuneSelf {
+			c.wrQuotedRune(dest, rune(b), quote, ascii)
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRune(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			c.wrEscape(dest, 'x', rune(b), 2) // Invalid UTF-8 byte
+		} else {
+			c.wrQuotedRune(dest, r, quote, ascii)
+		}
+		i += size
+	}
+	c.wrByte(dest, quote)
+}
+
+// wrQuotedRune writes r escaped for a literal delimited by quote
+func (c *Conv) wrQuotedRune(dest BuffDest, r rune, quote byte, ascii bool) {
+	if r == rune(quote) || r == '\\' {
+		c.wrByte(dest, '\\')
+		c.wrByte(dest, byte(r))
+		return
+	}
+	if r < utf8.RuneSelf && r >= ' ' && r != 0x7F {
+		c.wrByte(dest, byte(r))
+		return
+	}
+	if r >= utf8.RuneSelf && !ascii && isPrintRune(r) {
+		var enc [utf8.UTFMax]byte
+		n := utf8.EncodeRune(enc[:], r)
+		c.wrBytes(dest, enc[:n])
+		return
+	}
+	switch r {
+	case '\a':
+		c.WrString(dest, `\a`)
+	case '\b':
+		c.WrString(dest, `\b`)
+	case '\f':
+		c.WrString(dest, `\f`)
+	case '\n':
+		c.WrString(dest, `\n`)
+	case '\r':
+		c.WrString(dest, `\r`)
+	case '\t':
+		c.WrString(dest, `\t`)
+	case '\v':
+		c.WrString(dest, `\v`)
+	default:
+		switch {
+		case r < ' ' || r == 0x7F:
+			c.wrEscape(dest, 'x', r, 2)
+		case r < 0x10000:
+			c.wrEscape(dest, 'u', r, 4)
 		default:
-			c.wrByte(BuffWork, char)
+			c.wrEscape(dest, 'U', r, 8)
+		}
+	}
+}
+
+// wrEscape writes \x, \u or \U and r as the given number of lowercase hex digits
+func (c *Conv) wrEscape(dest BuffDest, kind byte, r rune, digits int) {
+	c.wrByte(dest, '\\')
+	c.wrByte(dest, kind)
+	for shift := (digits - 1) * 4; shift >= 0; shift -= 4 {
+		c.wrByte(dest, hexDigits[(r>>uint(shift))&0xF])
+	}
+}
+
+// isPrintRune reports whether r is printable like strconv.IsPrint: letters,
+// marks, numbers, punctuation, symbols and ' ', looked up in the range
+// tables of quote_print.go
+func isPrintRune(r rune) bool {
+	if r <= 0xFF {
+		return (r >= 0x20 && r <= 0x7E) || (r >= 0xA1 && r != 0xAD)
+	}
+	if r < 0x10000 {
+		rr := uint16(r)
+		i := searchUint16(isPrint16[:], rr)
+		if i >= len(isPrint16) || rr < isPrint16[i&^1] || isPrint16[i|1] < rr {
+			return false
+		}
+		j := searchUint16(isNotPrint16[:], rr)
+		return j >= len(isNotPrint16) || isNotPrint16[j] != rr
+	}
+	if r > utf8.MaxRune {
+		return false
+	}
+	rr := uint32(r)
+	i := searchUint32(isPrint32[:], rr)
+	if i >= len(isPrint32) || rr < isPrint32[i&^1] || isPrint32[i|1] < rr {
+		return false
+	}
+	if r >= 0x20000 {
+		return true
+	}
+	r16 := uint16(r - 0x10000)
+	j := searchUint16(isNotPrint32[:], r16)
+	return j >= len(isNotPrint32) || isNotPrint32[j] != r16
+}
+
+// searchUint16 returns the index of the first element of s not below v
+func searchUint16(s []uint16, v uint16) int {
+	i, j := 0, len(s)
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		if s[h] < v {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// searchUint32 is searchUint16 for uint32 tables
+func searchUint32(s []uint32, v uint32) int {
+	i, j := 0, len(s)
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		if s[h] < v {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// Unquote interprets the output as a Go string literal: double-quoted,
+// single-quoted (one character) or backquoted, like strconv.Unquote: invalid
+// UTF-8 inside quotes becomes U+FFFD and two single quotes give "".
+// Malformed input sets a translated error that wraps ErrSyntax.
+//
+//	Convert(`"a\tb\u00e9\x41"`).Unquote().String() // "a\tbéA"
+//	Convert("`raw\\n`").Unquote().String()         // `raw\n`
+//	_, err := Convert(`"bad\q"`).Unquote().StringErr() // Is(err, ErrSyntax)
+func (c *Conv) Unquote() *Conv {
+	if c.hasContent(BuffErr) {
+		return c // Error chain interruption
+	}
+	s := c.out[:c.outLen]
+	n := len(s)
+	if n < 2 || s[0] != s[n-1] || (s[0] != '"' && s[0] != '\'' && s[0] != '`') {
+		return c.wrErrKind(ErrSyntax, D.Format, D.Invalid)
+	}
+	quote := s[0]
+	s = s[1 : n-1]
+
+	c.ResetBuffer(BuffWork)
+	if quote == '`' {
+		for _, b := range s {
+			if b == '`' {
+				return c.wrErrKind(ErrSyntax, D.Character, D.Invalid, "'`'")
+			}
+			if b != '\r' { // Carriage returns are discarded in raw strings
+				c.wrByte(BuffWork, b)
+			}
 		}
+		c.swapBuff(BuffWork, BuffOut)
+		return c
 	}
 
-	c.wrByte(BuffWork, '"')
+	runes := 0
+	for i := 0; i < len(s); runes++ {
+		b := s[i]
+		if b == quote || b == '\n' {
+			return c.wrErrKind(ErrSyntax, D.Character, D.Invalid, QuoteRune(rune(b)))
+		}
+		if b != '\\' {
+			r, size := utf8.DecodeRune(s[i:])
+			if r == utf8.RuneError && size == 1 {
+				c.WrString(BuffWork, "\uFFFD") // Invalid UTF-8, like strconv
+			} else {
+				c.wrBytes(BuffWork, s[i:i+size])
+			}
+			i += size
+			continue
+		}
+		size, ok := c.wrUnescape(BuffWork, s[i:], quote)
+		if !ok {
+			end := i + size
+			if end > len(s) {
+				end = len(s)
+			}
+			return c.wrErrKind(ErrSyntax, D.Character, D.Invalid, string(s[i:end]))
+		}
+		i += size
+	}
+	if quote == '\'' && runes > 1 { // '' is accepted like strconv
+		return c.wrErrKind(ErrSyntax, D.Format, D.Invalid)
+	}
 	c.swapBuff(BuffWork, BuffOut)
 	return c
 }
+
+// wrUnescape decodes the escape sequence at the start of s into dest and
+// returns its length; ok is false for malformed escapes
+func (c *Conv) wrUnescape(dest BuffDest, s []byte, quote byte) (size int, ok bool) {
+	if len(s) < 2 {
+		return len(s), false
+	}
+	switch e := s[1]; e {
+	case 'a':
+		c.wrByte(dest, '\a')
+	case 'b':
+		c.wrByte(dest, '\b')
+	case 'f':
+		c.wrByte(dest, '\f')
+	case 'n':
+		c.wrByte(dest, '\n')
+	case 'r':
+		c.wrByte(dest, '\r')
+	case 't':
+		c.wrByte(dest, '\t')
+	case 'v':
+		c.wrByte(dest, '\v')
+	case '\\':
+		c.wrByte(dest, '\\')
+	case '\'', '"':
+		if e != quote {
+			return 2, false
+		}
+		c.wrByte(dest, e)
+	case 'x', 'u', 'U':
+		digits := 2
+		if e == 'u' {
+			digits = 4
+		} else if e == 'U' {
+			digits = 8
+		}
+		if len(s) < 2+digits {
+			return len(s), false
+		}
+		var r rune
+		for _, h := range s[2 : 2+digits] {
+			v := hexValue(h)
+			if v < 0 {
+				return 2 + digits, false
+			}
+			r = r<<4 | rune(v)
+		}
+		if e == 'x' {
+			c.wrByte(dest, byte(r)) // Byte value, not a rune
+		} else {
+			if !utf8.ValidRune(r) {
+				return 2 + digits, false
+			}
+			var enc [utf8.UTFMax]byte
+			n := utf8.EncodeRune(enc[:], r)
+			c.wrBytes(dest, enc[:n])
+		}
+		return 2 + digits, true
+	case '0', '1', '2', '3', '4', '5', '6', '7':
+		if len(s) < 4 {
+			return len(s), false
+		}
+		var v int
+		for _, o := range s[1:4] {
+			if o < '0' || o > '7' {
+				return 4, false
+			}
+			v = v<<3 | int(o-'0')
+		}
+		if v > 255 {
+			return 4, false
+		}
+		c.wrByte(dest, byte(v))
+		return 4, true
+	default:
+		return 2, false
+	}
+	return 2, true
+}
@@ -1,6 +1,9 @@
 package fmt
 
-import "testing"
+import (
+	"strconv"
+	"testing"
+)
 
 func TestQuote(t *testing.T) {
 	tests := []struct {
@@ -87,3 +90,172 @@ func TestQuoteChaining(t *testing.T) {
 		t.Errorf("Quote after conversion = %q, want %q", result2, expected2)
 	}
 }
+
+func TestQuoteEscapes(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		want  string
+		ascii string
+	}{
+		{"control bytes", "a\x00b\x1f\x7f", `"a\x00b\x1f\x7f"`, `"a\x00b\x1f\x7f"`},
+		{"short escapes", "\a\b\f\v", `"\a\b\f\v"`, `"\a\b\f\v"`},
+		{"unicode printable", "a\u00f1o \u263a", "\"a\u00f1o \u263a\"", `"a\u00f1o \u263a"`},
+		{"line separator", "a\u2028b", `"a\u2028b"`, `"a\u2028b"`},
+		{"no-break space", "a\u00a0b", `"a\u00a0b"`, `"a\u00a0b"`},
+		{"bom", "\ufeffx", `"\ufeffx"`, `"\ufeffx"`},
+		{"astral", "\U0001F600", "\"\U0001F600\"", `"\U0001f600"`},
+		{"tag character", "\U000E0001", `"\U000e0001"`, `"\U000e0001"`},
+		{"invalid utf8", "a\xffb\xc3", `"a\xffb\xc3"`, `"a\xffb\xc3"`},
+		{"single quote kept", "it's", `"it's"`, `"it's"`},
+	}
+	for _, tt := range tests {
+		if got := Convert(tt.in).Quote().String(); got != tt.want {
+			t.Errorf("%s: Quote = %s, want %s", tt.name, got, tt.want)
+		}
+		if got := Convert(tt.in).QuoteToASCII().String(); got != tt.ascii {
+			t.Errorf("%s: QuoteToASCII = %s, want %s", tt.name, got, tt.ascii)
+		}
+	}
+}
+
+func TestQuoteRune(t *testing.T) {
+	tests := []struct {
+		r     rune
+		want  string
+		ascii string
+	}{
+		{'a', `'a'`, `'a'`},
+		{'\'', `'\''`, `'\''`},
+		{'"', `'"'`, `'"'`},
+		{'\n', `'\n'`, `'\n'`},
+		{0, `'\x00'`, `'\x00'`},
+		{'\u263a', "'\u263a'", `'\u263a'`},
+		{0xD800, "'\ufffd'", `'\ufffd'`},
+	}
+	for _, tt := range tests {
+		if got := QuoteRune(tt.r); got != tt.want {
+			t.Errorf("QuoteRune(%d) = %s, want %s", tt.r, got, tt.want)
+		}
+		if got := QuoteRuneToASCII(tt.r); got != tt.ascii {
+			t.Errorf("QuoteRuneToASCII(%d) = %s, want %s", tt.r, got, tt.ascii)
+		}
+	}
+	if got := Fmt("%q %q", "a\tb", 'x'); got != `"a\tb" 'x'` {
+		t.Errorf("Fmt %%q = %s", got)
+	}
+}
+
+func TestQuoteMatchesStrconv(t *testing.T) {
+	// Unassigned (U+0378, U+1D173 format), prepended concatenation mark
+	// (U+0600), bidi control (U+061C) and printable runes around them
+	runes := []rune{0x378, 0x600, 0x61C, 0x1D173, 0x37A, 0x5D0, 0x1D165, 0xE0001,
+		0x20000, 0x2FFFE, 0x10FFFF, 0xFFFD, 0xAD, 0xA0, 0x2028, 0x1F600, 0x2066}
+	for _, r := range runes {
+		s := "a" + string(r) + "b"
+		if got, want := Convert(s).Quote().String(), strconv.Quote(s); got != want {
+			t.Errorf("Quote(%U) = %s, want %s", r, got, want)
+		}
+		if got, want := QuoteRune(r), strconv.QuoteRune(r); got != want {
+			t.Errorf("QuoteRune(%U) = %s, want %s", r, got, want)
+		}
+		if got, want := isPrintRune(r), strconv.IsPrint(r); got != want {
+			t.Errorf("isPrintRune(%U) = %v, want %v", r, got, want)
+		}
+	}
+
+	for _, in := range []string{`''`, "'\xff'", "\"\xff\"", "\"a\xc3b\"", `'ab'`, `"\xff"`} {
+		want, werr := strconv.Unquote(in)
+		got, err := Convert(in).Unquote().StringErr()
+		if got != want || (err == nil) != (werr == nil) {
+			t.Errorf("Unquote(%q) = %q, %v; strconv %q, %v", in, got, err, want, werr)
+		}
+	}
+}
+
+func TestBackquote(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`C:\path`, "`C:\\path`"},
+		{"tab\there", "`tab\there`"},
+		{"has `tick`", "\"has `tick`\""},
+		{"line\nbreak", `"line\nbreak"`},
+		{"bad\xff", `"bad\xff"`},
+	}
+	for _, tt := range tests {
+		if got := Convert(tt.in).Backquote().String(); got != tt.want {
+			t.Errorf("Backquote(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+	if CanBackquote("a\u00f1o") != true || CanBackquote("\ufeff") != false {
+		t.Error("unexpected CanBackquote result")
+	}
+}
+
+func TestUnquote(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`"hello"`, "hello"},
+		{`""`, ""},
+		{`"a\tb\u00e9\x41"`, "a\tb\u00e9A"},
+		{`"\a\b\f\n\r\v\\\""`, "\a\b\f\n\r\v\\\""},
+		{`"\101\x42\u0043\U0001F600"`, "ABC\U0001F600"},
+		{`"\xff"`, "\xff"},
+		{`"it's"`, "it's"},
+		{`'a'`, "a"},
+		{`'\''`, "'"},
+		{`'\u263a'`, "\u263a"},
+		{"`raw\\n\r\nline`", "raw\\n\nline"},
+		{`''`, ""},                 // Accepted like strconv
+		{"\"a\xffb\"", "a\uFFFDb"}, // Invalid UTF-8 becomes U+FFFD
+		{"'\xff'", "\uFFFD"},
+		{"`\xff`", "\xff"}, // Raw strings keep bytes
+	}
+	for _, tt := range tests {
+		got, err := Convert(tt.in).Unquote().StringErr()
+		if err != nil {
+			t.Errorf("Unquote(%s): unexpected error %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Unquote(%s) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	// Round trip through Quote and QuoteToASCII
+	for _, s := range []string{"a\x00\u2028\U0001F600\xff\"\\", "plain", "\u00f1\t"} {
+		for _, q := range []string{Convert(s).Quote().String(), Convert(s).QuoteToASCII().String()} {
+			if got := Convert(q).Unquote().String(); got != s {
+				t.Errorf("round trip %s: got %q, want %q", q, got, s)
+			}
+		}
+	}
+}
+
+func TestUnquoteErrors(t *testing.T) {
+	bad := []string{
+		``, `"`, `abc`, `"abc'`, `"a"b"`, "\"a\nb\"", `"\q"`, `"\x4"`, `"\xzz"`,
+		`"\400"`, `"\ud800"`, `"\U00110000"`, `'ab'`, `'\"'`, `"\'"`, "`a`b`", `"\`,
+	}
+	for _, in := range bad {
+		_, err := Convert(in).Unquote().StringErr()
+		if err == nil {
+			t.Errorf("Unquote(%s): expected error", in)
+			continue
+		}
+		if !Is(err, ErrSyntax) {
+			t.Errorf("Unquote(%s): expected ErrSyntax, got %v", in, err)
+		}
+	}
+
+	OutLang(ES)
+	defer OutLang(EN)
+	_, err := Convert(`"\q"`).Unquote().StringErr()
+	if err == nil || err.Error() != `Caracter Inválido \q` {
+		t.Errorf("expected translated error, got %v", err)
+	}
+}
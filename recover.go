@@ -0,0 +1,52 @@
+package fmt
+
+import "reflect"
+
+// Recover converts a panic into an error assigned to *err. It must be
+// deferred directly:
+//
+//	func handler() (err error) {
+//		defer Recover(&err)
+//		...
+//	}
+//
+// The message is the translated "Panic" prefix followed by the panic value
+// converted like any other value (strings, numbers, Stringers, errors...):
+// panic("boom") => "Panic: boom". Error values are wrapped, so Is and As see
+// them. Build with -tags fmtdebug to include the panic stack in %+v.
+// Without a panic, *err is left unchanged.
+func Recover(err *error) {
+	if r := recover(); r != nil && err != nil {
+		*err = panicErr(r)
+	}
+}
+
+// Try runs fn and returns the panic it raised as an error (see Recover),
+// or nil when fn returns normally.
+//
+//	err := Try(func() { process(items) })
+func Try(fn func()) (err error) {
+	defer Recover(&err)
+	fn()
+	return nil
+}
+
+// panicErr builds the error for a recovered panic value
+func panicErr(r any) *Error {
+	format := "%L: %v"
+	switch r.(type) {
+	case LocStr, *LocStr:
+		format = "%L: %L" // panic(D.Something) is translated too
+	}
+	c := GetConv()
+	c.wrErrf(getCurrentLang(), format, D.Panic, r)
+	if c.hasContent(BuffErr) && c.errFormat == "" {
+		// The value could not be converted: report its type instead
+		c.ResetBuffer(BuffErr)
+		c.wrErrf(getCurrentLang(), "%L: %s", D.Panic, reflect.TypeOf(r).String())
+	}
+	if e, ok := r.(error); ok {
+		c.wrapped = append(c.wrapped, e)
+	}
+	return c.releaseErr()
+}
@@ -0,0 +1,88 @@
+package fmt
+
+import "testing"
+
+type testStringer struct{}
+
+func (testStringer) String() string { return "stringer value" }
+
+func TestRecover(t *testing.T) {
+	OutLang(EN)
+	defer OutLang(EN)
+
+	inner := Err(D.Format, D.Invalid)
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{"string", "boom", "Panic: boom"},
+		{"error", inner, "Panic: Format Invalid"},
+		{"int", 42, "Panic: 42"},
+		{"float", 2.5, "Panic: 2.5"},
+		{"bool", true, "Panic: true"},
+		{"Stringer", testStringer{}, "Panic: stringer value"},
+		{"LocStr", D.Empty, "Panic: Empty"},
+		{"unsupported", struct{ A int }{1}, "Panic: struct { A int }"},
+	}
+	for _, tt := range tests {
+		err := Try(func() { panic(tt.value) })
+		if err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+			continue
+		}
+		if got := err.Error(); got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.want, got)
+		}
+	}
+
+	t.Run("no panic", func(t *testing.T) {
+		if err := Try(func() {}); err != nil {
+			t.Errorf("expected nil, got %v", err)
+		}
+	})
+
+	t.Run("wraps errors", func(t *testing.T) {
+		err := Try(func() { panic(inner) })
+		if !Is(err, inner) {
+			t.Error("expected the panic error to be wrapped")
+		}
+		if got := err.(*Error).Code(); got != "panic" {
+			t.Errorf("expected code 'panic', got %q", got)
+		}
+	})
+
+	t.Run("runtime error", func(t *testing.T) {
+		err := Try(func() {
+			var m map[string]int
+			m["x"] = 1
+		})
+		var re interface{ RuntimeError() }
+		if !As(err, &re) {
+			t.Errorf("expected runtime.Error to be wrapped, got %v", err)
+		}
+	})
+
+	t.Run("Recover keeps existing error without panic", func(t *testing.T) {
+		prev := Err("previous")
+		fn := func() (err error) {
+			defer Recover(&err)
+			return prev
+		}
+		if err := fn(); err != prev {
+			t.Errorf("expected previous error, got %v", err)
+		}
+	})
+
+	t.Run("translated prefix", func(t *testing.T) {
+		OutLang(ES)
+		defer OutLang(EN)
+		err := Try(func() { panic("boom") })
+		if got := err.Error(); got != "Pánico: boom" {
+			t.Errorf("expected 'Pánico: boom', got %q", got)
+		}
+		if got := err.(*Error).In(DE); got != "Panik: boom" {
+			t.Errorf("expected 'Panik: boom', got %q", got)
+		}
+	})
+}
@@ -0,0 +1,278 @@
+package fmt
+
+import "io"
+
+// =============================================================================
+// SERVER-SENT EVENTS - text/event-stream framing shared by server and WASM client
+// =============================================================================
+
+// SSEEvent is one Server-Sent Event
+type SSEEvent struct {
+	Type  MessageType // set from Event when decoding; names the event when Event is empty
+	Event string      // event name; empty means the default "message" event
+	ID    string      // event id; the decoder repeats the last id seen
+	Data  string      // payload; lines are separated by "\n"
+	Retry int         // reconnection time in milliseconds, 0 when not set
+}
+
+// Name returns the event name written to the stream: Event when set,
+// otherwise the lowercase name of Type ("error", "timeout"...),
+// or "" for Msg.Normal (the default "message" event)
+func (ev SSEEvent) Name() string {
+	if ev.Event != "" {
+		return ev.Event
+	}
+	return sseTypeName(ev.Type)
+}
+
+// String returns the event framed for a text/event-stream response:
+//
+//	SSEEvent{Type: Msg.Error, ID: "7", Data: "line 1\nline 2"}.String()
+//	// "id: 7\nevent: error\ndata: line 1\ndata: line 2\n\n"
+//
+// Data is split on "\n", "\r\n" and "\r" into one data line each.
+// Line breaks in the id and name are removed so they cannot inject fields.
+func (ev SSEEvent) String() string {
+	c := GetConv()
+	c.wrSSE(ev)
+	return c.String()
+}
+
+// WriteSSE writes the framed event to w (see SSEEvent.String).
+// Flush the writer afterwards when it buffers (e.g. http.Flusher).
+func WriteSSE(w io.Writer, ev SSEEvent) (int, error) {
+	c := GetConv()
+	defer c.putConv()
+	c.wrSSE(ev)
+	return w.Write(c.getBytes(BuffOut))
+}
+
+// wrSSE writes the framed event to BuffOut
+func (c *Conv) wrSSE(ev SSEEvent) {
+	if ev.ID != "" {
+		c.wrSSEField("id", ev.ID)
+	}
+	if name := ev.Name(); name != "" {
+		c.wrSSEField("event", name)
+	}
+	if ev.Retry > 0 {
+		c.WrString(BuffOut, "retry: ")
+		c.wrIntBase(BuffOut, int64(ev.Retry), 10, true)
+		c.wrByte(BuffOut, '\n')
+	}
+	data := ev.Data
+	for {
+		end, next := sseLineEnd(data)
+		c.WrString(BuffOut, "data: ")
+		c.WrString(BuffOut, data[:end])
+		c.wrByte(BuffOut, '\n')
+		if next < 0 {
+			break
+		}
+		data = data[next:]
+	}
+	c.wrByte(BuffOut, '\n')
+}
+
+// wrSSEField writes "name: value\n" dropping line breaks from value
+func (c *Conv) wrSSEField(name, value string) {
+	c.WrString(BuffOut, name)
+	c.WrString(BuffOut, ": ")
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\n' && value[i] != '\r' {
+			c.wrByte(BuffOut, value[i])
+		}
+	}
+	c.wrByte(BuffOut, '\n')
+}
+
+// sseLineEnd returns the end of the first line of s and the start of the
+// next one, or -1 when s has no line break
+func sseLineEnd(s string) (end, next int) {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\n':
+			return i, i + 1
+		case '\r':
+			if i+1 < len(s) && s[i+1] == '\n' {
+				return i, i + 2
+			}
+			return i, i + 1
+		}
+	}
+	return len(s), -1
+}
+
+// sseTypeName returns the event name for a MessageType: its lowercase name,
+// or "" for Msg.Normal
+func sseTypeName(t MessageType) string {
+	if t == Msg.Normal {
+		return ""
+	}
+	return Convert(t.String()).ToLower().String()
+}
+
+// SSEType returns the MessageType named by an event ("error", "Timeout"...),
+// or Msg.Normal for "message", "" and unknown names
+func SSEType(event string) MessageType {
+	if event == "" {
+		return Msg.Normal
+	}
+	// int counter: a uint8 one would wrap and never pass a last type of 255
+	for i, last := int(Msg.Info), int(lastMsgType()); i <= last; i++ {
+		if t := MessageType(i); sseTypeName(t) == event || t.String() == event {
+			return t
+		}
+	}
+	return Msg.Normal
+}
+
+// SSEDecoder parses a text/event-stream incrementally. Feed it chunks as they
+// arrive (fetch stream reads, HTTP body reads); it keeps incomplete lines
+// between calls. The zero value is ready to use.
+//
+//	var dec SSEDecoder
+//	for _, ev := range dec.Feed(chunk) {
+//		if ev.Type.IsError() { ... }
+//	}
+type SSEDecoder struct {
+	line    []byte // incomplete line carried between chunks
+	skipLF  bool   // previous chunk ended with '\r': ignore a leading '\n'
+	started bool   // first line seen (a leading BOM is skipped)
+
+	event   string
+	data    []byte
+	hasData bool
+	lastID  string
+	retry   int // retry field of the event being parsed
+	wait    int // last retry value received (see Retry)
+}
+
+// Feed parses chunk and returns the events it completed, in order.
+// Lines are split on "\n", "\r\n" or "\r"; comments (":...") are ignored.
+func (d *SSEDecoder) Feed(chunk []byte) []SSEEvent {
+	var out []SSEEvent
+	for _, b := range chunk {
+		if d.skipLF {
+			d.skipLF = false
+			if b == '\n' {
+				continue
+			}
+		}
+		switch b {
+		case '\r':
+			d.skipLF = true
+			fallthrough
+		case '\n':
+			if ev, ok := d.processLine(); ok {
+				out = append(out, ev)
+			}
+			d.line = d.line[:0]
+		default:
+			d.line = append(d.line, b)
+		}
+	}
+	return out
+}
+
+// Decode reads r until EOF or an error, calling fn for each event.
+// It stops early, returning nil, when fn returns false.
+func (d *SSEDecoder) Decode(r io.Reader, fn func(SSEEvent) bool) error {
+	var buf [512]byte
+	for {
+		n, err := r.Read(buf[:])
+		for _, ev := range d.Feed(buf[:n]) {
+			if !fn(ev) {
+				return nil
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// LastID returns the last event id received, to send as Last-Event-ID on reconnection
+func (d *SSEDecoder) LastID() string {
+	return d.lastID
+}
+
+// Retry returns the last reconnection time received in milliseconds, 0 if none.
+// Retry fields apply even when their block carries no data.
+func (d *SSEDecoder) Retry() int {
+	return d.wait
+}
+
+// processLine applies the current line; a blank line completes an event
+func (d *SSEDecoder) processLine() (SSEEvent, bool) {
+	line := d.line
+	if !d.started {
+		d.started = true
+		if len(line) >= 3 && line[0] == 0xEF && line[1] == 0xBB && line[2] == 0xBF {
+			line = line[3:]
+		}
+	}
+	if len(line) == 0 {
+		return d.dispatch()
+	}
+	if line[0] == ':' {
+		return SSEEvent{}, false // Comment (e.g. keep-alive)
+	}
+	field, value := line, []byte(nil)
+	for i, b := range line {
+		if b == ':' {
+			field, value = line[:i], line[i+1:]
+			if len(value) > 0 && value[0] == ' ' {
+				value = value[1:]
+			}
+			break
+		}
+	}
+	switch string(field) {
+	case "event":
+		d.event = string(value)
+	case "data":
+		if d.hasData {
+			d.data = append(d.data, '\n')
+		}
+		d.data = append(d.data, value...)
+		d.hasData = true
+	case "id":
+		for _, b := range value {
+			if b == 0 {
+				return SSEEvent{}, false // ids with NUL are ignored
+			}
+		}
+		d.lastID = string(value)
+	case "retry":
+		if len(value) > 0 && isDigitStr(string(value)) {
+			n := 0
+			for _, b := range value {
+				n = n*10 + int(b-'0')
+			}
+			d.retry, d.wait = n, n
+		}
+	}
+	return SSEEvent{}, false
+}
+
+// dispatch returns the buffered event and resets the event state.
+// Events without data are not dispatched.
+func (d *SSEDecoder) dispatch() (SSEEvent, bool) {
+	if !d.hasData {
+		d.event, d.retry = "", 0
+		return SSEEvent{}, false
+	}
+	ev := SSEEvent{
+		Type:  SSEType(d.event),
+		Event: d.event,
+		ID:    d.lastID,
+		Data:  string(d.data),
+		Retry: d.retry,
+	}
+	d.event, d.data, d.hasData, d.retry = "", d.data[:0], false, 0
+	return ev, true
+}
@@ -0,0 +1,150 @@
+package fmt
+
+import (
+	"io"
+	"testing"
+)
+
+func TestSSEEncode(t *testing.T) {
+	tests := []struct {
+		name string
+		ev   SSEEvent
+		want string
+	}{
+		{"data only", SSEEvent{Data: "hello"}, "data: hello\n\n"},
+		{"type name", SSEEvent{Type: Msg.Error, ID: "7", Data: "boom"}, "id: 7\nevent: error\ndata: boom\n\n"},
+		{"explicit event", SSEEvent{Type: Msg.Error, Event: "update", Data: "x"}, "event: update\ndata: x\n\n"},
+		{"multi-line", SSEEvent{Data: "a\nb\r\nc\rd"}, "data: a\ndata: b\ndata: c\ndata: d\n\n"},
+		{"empty data", SSEEvent{Type: Msg.Timeout}, "event: timeout\ndata: \n\n"},
+		{"retry", SSEEvent{Retry: 3000, Data: "r"}, "retry: 3000\ndata: r\n\n"},
+		{"injection", SSEEvent{ID: "1\ndata: x", Event: "a\rb", Data: "d"}, "id: 1data: x\nevent: ab\ndata: d\n\n"},
+	}
+	for _, tt := range tests {
+		if got := tt.ev.String(); got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.want, got)
+		}
+	}
+
+	w := &testLogWriter{}
+	if n, err := WriteSSE(w, SSEEvent{Type: Msg.Success, Data: "ok"}); err != nil || n != len(w.data) {
+		t.Fatalf("unexpected WriteSSE result %d %v", n, err)
+	}
+	if got := string(w.data); got != "event: success\ndata: ok\n\n" {
+		t.Errorf("unexpected WriteSSE output %q", got)
+	}
+}
+
+func TestSSEDecode(t *testing.T) {
+	stream := "\xEF\xBB\xBFdata: bom\n\n: keep-alive\n" +
+		"id: 1\nevent: error\ndata: line 1\ndata: line 2\n\n" +
+		"data:no space\r\n\r\n" +
+		"event: timeout\rretry: 5000\rdata: slow\r\r" +
+		"id: 2\n\n" + // no data: not dispatched, id kept
+		"event: custom\ndata\n\n" +
+		"data: incomplete"
+
+	want := []SSEEvent{
+		{Type: Msg.Normal, Data: "bom"},
+		{Type: Msg.Error, Event: "error", ID: "1", Data: "line 1\nline 2"},
+		{Type: Msg.Normal, ID: "1", Data: "no space"},
+		{Type: Msg.Timeout, Event: "timeout", ID: "1", Data: "slow", Retry: 5000},
+		{Type: Msg.Normal, Event: "custom", ID: "2", Data: ""},
+	}
+
+	check := func(t *testing.T, got []SSEEvent, dec *SSEDecoder) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("expected %d events, got %d: %+v", len(want), len(got), got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("event %d: expected %+v, got %+v", i, want[i], got[i])
+			}
+		}
+		if dec.LastID() != "2" || dec.Retry() != 5000 {
+			t.Errorf("unexpected decoder state id=%q retry=%d", dec.LastID(), dec.Retry())
+		}
+	}
+
+	t.Run("one chunk", func(t *testing.T) {
+		var dec SSEDecoder
+		check(t, dec.Feed([]byte(stream)), &dec)
+	})
+
+	t.Run("byte by byte", func(t *testing.T) {
+		var dec SSEDecoder
+		var got []SSEEvent
+		for i := 0; i < len(stream); i++ {
+			got = append(got, dec.Feed([]byte{stream[i]})...)
+		}
+		check(t, got, &dec)
+	})
+
+	t.Run("reader", func(t *testing.T) {
+		var dec SSEDecoder
+		var got []SSEEvent
+		r := &testChunkReader{data: []byte(stream), size: 7}
+		if err := dec.Decode(r, func(ev SSEEvent) bool { got = append(got, ev); return true }); err != nil {
+			t.Fatal(err)
+		}
+		check(t, got, &dec)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		var dec SSEDecoder
+		ev := SSEEvent{Type: Msg.Auth, ID: "9", Data: "a\nb"}
+		got := dec.Feed([]byte(ev.String()))
+		if len(got) != 1 || got[0].Type != Msg.Auth || got[0].Data != "a\nb" || got[0].ID != "9" {
+			t.Errorf("unexpected round trip %+v", got)
+		}
+	})
+}
+
+func TestSSEType(t *testing.T) {
+	for _, name := range []string{"", "message", "unknown"} {
+		if got := SSEType(name); got != Msg.Normal {
+			t.Errorf("%q: expected Normal, got %v", name, got)
+		}
+	}
+	if SSEType("broadcast") != Msg.Broadcast || SSEType("Warning") != Msg.Warning {
+		t.Error("expected type names to map to their MessageType")
+	}
+}
+
+func TestSSETypeFullRegistry(t *testing.T) {
+	keepMessageTypes(t)
+	last := Msg.Normal
+	for i := 0; ; i++ {
+		mt := AddMessageType("SSEFull"+Convert(i).String(), LocStr{}, Ansi.Cyan)
+		if mt == Msg.Normal {
+			break
+		}
+		last = mt
+	}
+	if last != 255 {
+		t.Fatalf("expected registration up to 255, got %d", last)
+	}
+	// Must return instead of wrapping past 255
+	if got := SSEType("unknown"); got != Msg.Normal {
+		t.Errorf("expected Normal, got %v", got)
+	}
+	if got := SSEType("ssefull0"); got == Msg.Normal {
+		t.Error("expected registered type to be found")
+	}
+}
+
+// testChunkReader returns data in chunks of size bytes
+type testChunkReader struct {
+	data []byte
+	size int
+}
+
+func (r *testChunkReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, io.EOF
+	}
+	n := min(r.size, len(r.data), len(p))
+	copy(p, r.data[:n])
+	r.data = r.data[n:]
+	return n, nil
+}
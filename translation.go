@@ -35,12 +35,20 @@ func Translate(values ...any) *Conv {
 // SmartArgs handles language detection, format string detection, and argument processing
 // This unifies logic between Html (detectFormat=true, allowStringCode=false) and Translate (detectFormat=false, allowStringCode=true)
 func (c *Conv) SmartArgs(dest BuffDest, separator string, allowStringCode bool, detectFormat bool, values ...any) *Conv {
+	return c.smartArgs(dest, getCurrentLang(), separator, allowStringCode, detectFormat, false, values...)
+}
+
+// smartArgs is SmartArgs with an explicit fallback language.
+// Used by LangScope so scoped output never reads the global default.
+// isolate enables bidi isolation of embedded values (see LangScope.Isolated);
+// it never applies to errors.
+func (c *Conv) smartArgs(dest BuffDest, defLang lang, separator string, allowStringCode bool, detectFormat bool, isolate bool, values ...any) *Conv {
 	if len(values) == 0 {
 		return c
 	}
 
 	// PASO 1: Detección de idioma
-	currentLang, startIdx := detectLanguage(c, values, allowStringCode)
+	currentLang, startIdx := detectLanguage(c, values, allowStringCode, defLang)
 
 	// Adjust values based on startIdx
 	args := values[startIdx:]
@@ -48,6 +56,11 @@ func (c *Conv) SmartArgs(dest BuffDest, separator string, allowStringCode bool,
 		return c
 	}
 
+	// Errors keep their parts so they can be rendered again in another language
+	if dest == BuffErr {
+		c.recordErr(currentLang, "", args)
+	}
+
 	// PASO 2: Detección de formato (Opcional, usado por Html)
 	if detectFormat {
 		if format, ok := args[0].(string); ok {
@@ -72,7 +85,7 @@ func (c *Conv) SmartArgs(dest BuffDest, separator string, allowStringCode bool,
 	}
 
 	// PASO 3: Procesamiento de argumentos traducidos
-	c.processTranslatedArgs(dest, args, currentLang, 0, separator)
+	c.processTranslatedArgs(dest, args, currentLang, 0, separator, detectFormat, isolate && dest != BuffErr)
 	return c
 }
 
@@ -83,9 +96,10 @@ func (c *Conv) SmartArgs(dest BuffDest, separator string, allowStringCode bool,
 // detectLanguage determines the current language and start index from variadic arguments
 // UNIFIED FUNCTION: Handles language detection for both Translate() and wrErr()
 // Returns: (language, startIndex) where startIndex skips the language argument if present
-func detectLanguage(c *Conv, args []any, allowStringCode bool) (lang, int) {
+// defLang is returned when no language argument is present.
+func detectLanguage(c *Conv, args []any, allowStringCode bool, defLang lang) (lang, int) {
 	if len(args) == 0 {
-		return getCurrentLang(), 0
+		return defLang, 0
 	}
 
 	// Check if first argument is a language specifier
@@ -102,26 +116,62 @@ func detectLanguage(c *Conv, args []any, allowStringCode bool) (lang, int) {
 	}
 
 	// No language specified, use default
-	return getCurrentLang(), 0
+	return defLang, 0
 }
 
 // processTranslatedArgs processes arguments with language-aware translation
 // UNIFIED FUNCTION: Handles argument processing for both Translate() and wrErr()
 // Eliminates code duplication between Translate() and wrErr()
 // REFACTORED: Uses WrString instead of direct buffer access
-func (c *Conv) processTranslatedArgs(dest BuffDest, args []any, currentLang lang, startIndex int, separator string) {
+// With isolate set, embedded values in right-to-left languages are isolated:
+// plain text output wraps strings and values holding left-to-right content in
+// Unicode isolates, html output wraps non-string values in <bdi> (strings are
+// markup there).
+func (c *Conv) processTranslatedArgs(dest BuffDest, args []any, currentLang lang, startIndex int, separator string, html bool, isolate bool) {
+	rtl := isolate && currentLang.IsRTL()
 	for i := startIndex; i < len(args); i++ {
 		arg := args[i]
 		switch v := arg.(type) {
 		case LocStr:
 			c.wrTranslation(v, currentLang, dest)
+		case *LocStr:
+			if v != nil {
+				c.wrTranslation(*v, currentLang, dest)
+			}
+		case error:
+			// Error arguments render their message (Err wraps them, see Unwrap)
+			if v != nil {
+				c.WrString(dest, v.Error())
+			}
 		case string:
-			c.WrString(dest, v)
+			if rtl && !html && needsIsolation(v) {
+				c.WrString(dest, fsiStr)
+				c.WrString(dest, v)
+				c.WrString(dest, pdiStr)
+			} else {
+				c.WrString(dest, v)
+			}
 		default:
-			c.AnyToBuff(BuffWork, v)
+			// Convert with a pooled Conv: AnyToBuff resets the error buffer and
+			// type state, which would erase an error being built in dest
+			tmp := GetConv()
+			tmp.AnyToBuff(BuffOut, v)
+			c.ResetBuffer(BuffWork)
+			c.wrBytes(BuffWork, tmp.getBytes(BuffOut))
+			tmp.putConv()
 			if c.hasContent(BuffWork) {
 				workResult := c.GetString(BuffWork)
-				c.WrString(dest, workResult)
+				if rtl && html {
+					c.WrString(dest, bdiOpen)
+					c.WrString(dest, workResult)
+					c.WrString(dest, bdiClose)
+				} else if rtl && needsIsolation(workResult) {
+					c.WrString(dest, fsiStr)
+					c.WrString(dest, workResult)
+					c.WrString(dest, pdiStr)
+				} else {
+					c.WrString(dest, workResult)
+				}
 				c.ResetBuffer(BuffWork)
 			}
 		}
@@ -170,6 +220,20 @@ func shouldAddSpace(args []any, currentIndex int) bool {
 // REUSES: existing LocStr array indexing logic
 // METHOD: Now a Conv method that writes directly to buffer without returning anything
 func (c *Conv) wrTranslation(locStr LocStr, currentLang lang, dest BuffDest) {
+	// Regional variant: use its override when present, otherwise its base language
+	if currentLang >= numLangs {
+		if v := getVariant(currentLang); v != nil {
+			variantsMu.RLock()
+			text, ok := v.words[locStr[EN]]
+			variantsMu.RUnlock()
+			if ok {
+				c.WrString(dest, text)
+				return
+			}
+		}
+		currentLang = currentLang.baseLang()
+	}
+
 	// Get translation for current language with fallback
 	var translation string
 	if int(currentLang) < len(locStr) && locStr[currentLang] != "" {
@@ -53,24 +53,30 @@ func (c *Conv) validateIntParam(param any, allowZero bool) (int, bool) {
 
 // truncateWithEllipsis helper method to reduce code duplication
 // Handles the common pattern of truncating content and adding ellipsis
+// Cuts are moved back to a rune boundary so multi-byte text (e.g. Arabic) stays valid UTF-8
+// ANSI escape sequences take no width and are kept (see visiblePrefix)
 func (c *Conv) truncateWithEllipsis(content string, maxWidth int) {
 	ellipsisLen := len(ellipsisStr)
+	keep := maxWidth
 	if maxWidth >= ellipsisLen {
-		contentToKeep := min(max(maxWidth-ellipsisLen, 0), len(content))
-		c.ResetBuffer(BuffOut)                       // Clear buffer using API
-		c.WrString(BuffOut, content[:contentToKeep]) // Write content using API
-		c.WrString(BuffOut, ellipsisStr)             // Append ellipsis using API
+		keep = max(maxWidth-ellipsisLen, 0)
+	}
+	c.ResetBuffer(BuffOut) // Clear buffer using API
+	if hasEscape(content) {
+		c.WrString(BuffOut, visiblePrefix(content, keep, false))
 	} else {
-		// Ellipsis doesn't fit, just truncate
-		contentToKeep := min(maxWidth, len(content))
-		c.ResetBuffer(BuffOut)                       // Clear buffer using API
-		c.WrString(BuffOut, content[:contentToKeep]) // Write using API
+		c.WrString(BuffOut, content[:runeBoundary(content, min(keep, len(content)))]) // Write content using API
+	}
+	if maxWidth >= ellipsisLen {
+		c.WrString(BuffOut, ellipsisStr) // Append ellipsis using API
 	}
 }
 
 // Truncate truncates a Conv so that it does not exceed the specified width.
 // If the Conv is longer, it truncates it and adds "..." if there is space.
 // If the Conv is shorter or equal to the width, it remains unchanged.
+// Width is counted in bytes; the cut never splits a multi-byte character (e.g. Arabic).
+// ANSI escape sequences (see Color) are kept and take no width.
 // The reservedChars parameter indicates how many characters should be reserved for suffixes.
 // This parameter is optional - if not provided, no characters are reserved (equivalent to passing 0).
 // eg: Convert("Hello, World!").Truncate(10) => "Hello, ..."
@@ -93,7 +99,12 @@ func (t *Conv) Truncate(maxWidth any, reservedChars ...any) *Conv {
 	}
 
 	// OPTIMIZED: Use direct buffer length check
-	if t.outLen > mWI {
+	width := t.outLen
+	styled := hasEscape(t.GetStringZeroCopy(BuffOut))
+	if styled {
+		width = visibleLen(t.GetStringZeroCopy(BuffOut), false)
+	}
+	if width > mWI {
 		// Get reserved chars value
 		rCI := 0
 		if len(reservedChars) > 0 {
@@ -119,10 +130,16 @@ func (t *Conv) Truncate(maxWidth any, reservedChars ...any) *Conv {
 			t.truncateWithEllipsis(Conv, mWI)
 		} else {
 			// Case 3: Ellipsis doesn't fit or reserved chars prevent it, just truncate
-			// OPTIMIZED: Direct buffer truncation
-			cTK := min(mWI, t.outLen)
-			t.outLen = cTK
-			t.out = t.out[:cTK]
+			// OPTIMIZED: Direct buffer truncation at a rune boundary
+			if styled {
+				cut := visiblePrefix(t.GetString(BuffOut), mWI, false)
+				t.ResetBuffer(BuffOut)
+				t.WrString(BuffOut, cut)
+			} else {
+				cTK := runeBoundary(t.GetStringZeroCopy(BuffOut), min(mWI, t.outLen))
+				t.outLen = cTK
+				t.out = t.out[:cTK]
+			}
 		}
 	}
 